@@ -0,0 +1,26 @@
+package structproto
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BindingErrors represents all the errors of a binding with the option
+// StructProtoResolveOption.CollectBindingErrors, they are sorted in the
+// field order of the prototype.
+type BindingErrors []error
+
+func (e BindingErrors) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d binding errors occurred", len(e))
+	for _, err := range e {
+		sb.WriteString("; ")
+		sb.WriteString(err.Error())
+	}
+	return sb.String()
+}
+
+// Unwrap returns the underlying errors.
+func (e BindingErrors) Unwrap() []error {
+	return e
+}
@@ -5,6 +5,21 @@ import "reflect"
 const (
 	RequiredFlag = "required"
 	BlankFlag    = "_"
+	NestedFlag   = "nested"
+	RemainFlag   = "remain"
+
+	DefaultAttr = "default"
+	RepeatAttr  = "repeat"
+
+	LayoutAttr   = "layout"
+	TimeZoneAttr = "tz"
+	UnixAttr     = "unix"
+
+	SepAttr   = "sep"
+	TrimAttr  = "trim"
+	QuoteAttr = "quote"
+
+	AliasSeparator = "|"
 )
 
 type (
@@ -12,6 +27,14 @@ type (
 		Name  string
 		Flags []string
 		Desc  string
+		// Default is the value bound to the field if no value received,
+		// nil means the field has no default value.
+		Default *string
+		// Aliases are the alternate names of the field, e.g. the legacy
+		// names before renaming.
+		Aliases []string
+		// Attrs are the key=value attributes of the field, e.g. sep=;
+		Attrs map[string]string
 	}
 
 	Unmarshaler interface {
@@ -22,6 +45,14 @@ type (
 		Bind(v interface{}) error
 	}
 
+	// AttrBinder is a ValueBinder which can be specialized by the
+	// attributes of the field it binds, e.g. layout=2006-01-02.
+	AttrBinder interface {
+		ValueBinder
+		WithAttrs(attrs map[string]string) ValueBinder
+	}
+
 	TagResolver       func(fieldname, token string) (*Tag, error)
+	NamingStrategy    func(name string) string
 	ValueBindProvider func(rv reflect.Value) ValueBinder
 )
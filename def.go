@@ -1,21 +1,101 @@
 package structproto
 
 import (
+	"context"
+	"encoding"
+	"encoding/json"
 	"reflect"
 
 	"github.com/Bofry/structproto/common"
+	"github.com/Bofry/structproto/valuebinder"
 )
 
 const (
 	RequiredFlag = common.RequiredFlag
 	BlankFlag    = common.BlankFlag
+	NestedFlag   = common.NestedFlag
+	RemainFlag   = common.RemainFlag
+
+	DefaultAttr = common.DefaultAttr
+	RepeatAttr  = common.RepeatAttr
+
+	LayoutAttr   = common.LayoutAttr
+	TimeZoneAttr = common.TimeZoneAttr
+	UnixAttr     = common.UnixAttr
+
+	SepAttr   = common.SepAttr
+	TrimAttr  = common.TrimAttr
+	QuoteAttr = common.QuoteAttr
+
+	NestedFieldNameSeparator = "."
+
+	JsonTagName = "json"
+
+	malformedTagNameChars = " \t\r\n*,;=|'\"`"
+)
+
+const (
+	// SkipUnexportedField ignores the unexported fields silently.
+	SkipUnexportedField UnexportedFieldPolicy = iota
+	// RejectUnexportedField fails the resolving if an unexported field
+	// can be resolved by the TagResolver.
+	RejectUnexportedField
+	// UnsafeBindUnexportedField resolves the unexported fields and writes
+	// them through package unsafe.
+	UnsafeBindUnexportedField
+)
+
+const (
+	// DeclarationFieldOrder traverses the fields in the order they are
+	// declared, the promoted and nested fields follow their owners.
+	DeclarationFieldOrder FieldOrder = iota
+	// NameFieldOrder traverses the fields in the order of their names.
+	NameFieldOrder
+)
+
+const (
+	// LastAliasWins binds the value arrives last if the field receives
+	// values by more than one of its names.
+	LastAliasWins AliasConflictPolicy = iota
+	// PrimaryAliasWins binds the value of the name declared first if the
+	// field receives values by more than one of its names.
+	PrimaryAliasWins
+	// RejectAliasConflict fails the binding if the field receives values
+	// by more than one of its names.
+	RejectAliasConflict
+)
+
+const (
+	// IgnoreUnknownKey drops the keys which match no field silently.
+	IgnoreUnknownKey UnknownKeyPolicy = iota
+	// WarnUnknownKey reports the keys which match no field to the handler
+	// OnUnknownKeys after binding.
+	WarnUnknownKey
+	// RejectUnknownKey fails the binding with *UnknownKeyError if some
+	// keys match no field.
+	RejectUnknownKey
+)
+
+const (
+	// LastKeyWins binds every value of a repeated key, the last one wins.
+	LastKeyWins RepeatedKeyPolicy = iota
+	// FirstKeyWins ignores the values of a key except the first one.
+	FirstKeyWins
+	// RejectRepeatedKey fails the binding if a key is received again.
+	RejectRepeatedKey
+	// AccumulateRepeatedKey appends the values of a repeated key to the
+	// slice field, it works as LastKeyWins on the other fields if it is
+	// the resolver default.
+	AccumulateRepeatedKey
 )
 
 type (
 	Unmarshaler       = common.Unmarshaler
 	ValueBindProvider = common.ValueBindProvider
 	ValueBinder       = common.ValueBinder
+	AttrBinder        = common.AttrBinder
 	TagResolver       = common.TagResolver
+	NamingStrategy    = common.NamingStrategy
 	Tag               = common.Tag
 
 	FieldValueEntity struct {
@@ -27,14 +107,39 @@ type (
 		Iterate() <-chan FieldValueEntity
 	}
 
+	// FieldValueSeq is a sequence of FieldValueEntity, it calls yield on
+	// each entity in order and stops if yield returns false.
+	FieldValueSeq func(yield func(FieldValueEntity) bool)
+
+	// SeqIterator is an Iterator which can be consumed as FieldValueSeq,
+	// BindIterator prefers Seq to bind without goroutines and channels.
+	SeqIterator interface {
+		Iterator
+		Seq() FieldValueSeq
+	}
+
+	// ContextIterator is an Iterator which stops feeding and closes the
+	// channel once ctx is done, so the consumer can quit early without
+	// leaving the producer blocked.
+	ContextIterator interface {
+		Iterator
+		IterateContext(ctx context.Context) <-chan FieldValueEntity
+	}
+
 	FieldInfo interface {
 		IDName() string
 		Name() string
 		Desc() string
 		Index() int
+		IndexPath() []int
 		FindFlag(predicate func(v string) bool) bool
 		HasFlag(v string) bool
+		Flags() []string
+		Attr(key string) (string, bool)
 		Tag() reflect.StructTag
+		TagName() string
+		Default() (string, bool)
+		Aliases() []string
 	}
 
 	StructBinder interface {
@@ -44,11 +149,101 @@ type (
 	}
 
 	StructProtoResolveOption struct {
-		TagName             string
-		TagResolver         TagResolver
+		TagName     string
+		TagResolver TagResolver
+		// TagNames are the fallback tag names looked up in order after
+		// TagName, e.g. []string{"env", "json"}; the first present tag
+		// of a field is resolved.
+		TagNames []string
+		// TagResolvers specifies the TagResolver by tag name, the tag
+		// "json" uses tagresolver.JsonTagResolver unless it is TagName or
+		// specified here; others use TagResolver.
+		TagResolvers map[string]TagResolver
+		// NamingStrategy converts the Go field names for the fields which
+		// have no explicit name in tag, see package naming.
+		NamingStrategy NamingStrategy
+		// StrictTags fails the resolving on the unknown flags and
+		// attributes, the malformed names and the conflicting tokens in
+		// tags, see RegisterTagFlags and RegisterTagAttrs.
+		StrictTags          bool
 		CheckDuplicateNames bool
+		// ResolveNestedStructs resolves the fields of struct or pointer to
+		// struct typed fields as "parent.child" names, the struct types
+		// which have no resolvable fields, implement an unmarshaler or are
+		// converted by valuebinder (Converters.IsKnownType) are still
+		// treated as a single field. Use NestedFlag to force it on an
+		// individual field.
+		ResolveNestedStructs bool
+		UnexportedFields     UnexportedFieldPolicy
+		FieldOrder           FieldOrder
+		KeyMatching          KeyMatching
+		AliasConflicts       AliasConflictPolicy
+		// OnDeprecatedAlias is called when a field is bound by one of its
+		// aliases rather than its name.
+		OnDeprecatedAlias DeprecatedAliasHandler
+		// CollectBindingErrors keeps binding the rest values on failure,
+		// BindFields, BindChan, BindIterator and BindMap return all the
+		// conversion failures and missing required fields as
+		// BindingErrors.
+		CollectBindingErrors bool
+		// UnknownKeys is the policy on the keys which match no field and
+		// none of the aliases, they are reported with the suggested names.
+		UnknownKeys UnknownKeyPolicy
+		// OnUnknownKeys is called after binding with WarnUnknownKey if
+		// some keys match no field.
+		OnUnknownKeys UnknownKeyHandler
+		// RepeatedKeys is the policy when a field receives values by the
+		// same key more than once, the attribute repeat=last|first|reject|
+		// accumulate overrides it on an individual field.
+		RepeatedKeys RepeatedKeyPolicy
+		// ListSeparator is the separator of the list elements in a string
+		// value, "," is used if missing; the attribute sep= overrides it
+		// on an individual field.
+		ListSeparator string
+		// TrimListElements trims the white spaces around the list elements
+		// in a string value, the attribute trim=true|false overrides it
+		// on an individual field.
+		TrimListElements bool
+		// QuoteListElements enables the double-quoted list elements which
+		// can contain the separator, e.g. `"a,b",c`; the attribute
+		// quote=true|false overrides it on an individual field.
+		QuoteListElements bool
+		// AtomicBinding binds into a copy of the target which is assigned
+		// back only if the whole binding succeeds, including the required
+		// fields check and StructBinder.Deinit. The struct pointers on
+		// the field paths and the pointer fields are copied as well.
+		AtomicBinding bool
+		// Converters is the ConverterRegistry which decides the struct
+		// types bound as a single field, and converts the default values
+		// if DefaultValueBindProvider is missing; valuebinder.DefaultConverters
+		// is used if missing. The resolved schemas are cached by it, pass
+		// its BuildStringBinder and friends to the Bind* methods.
+		Converters *valuebinder.ConverterRegistry
+		// DefaultValueBindProvider converts the default values declared
+		// in tags at resolve time, Converters.BuildStringBinder is used if
+		// missing. NOTE: the bindings assign copies of the converted
+		// values whatever ValueBindProvider the Bind* calls use, and the
+		// resolvers with a closure or a method value here are not cached;
+		// use Converters for a per-prototype ConverterRegistry.
+		DefaultValueBindProvider ValueBindProvider
 	}
 
+	UnexportedFieldPolicy int
+	FieldOrder            int
+	AliasConflictPolicy   int
+	UnknownKeyPolicy      int
+	RepeatedKeyPolicy     int
+
+	DeprecatedAliasHandler func(field FieldInfo, alias string)
+	UnknownKeyHandler      func(err *UnknownKeyError)
+
 	StructVisitor func(name string, rv reflect.Value, info FieldInfo)
 	StructMapper  func(field FieldInfo, rv reflect.Value) error
 )
+
+var (
+	typeOfUnmarshaler       = reflect.TypeOf((*Unmarshaler)(nil)).Elem()
+	typeOfTextUnmarshaler   = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	typeOfBinaryUnmarshaler = reflect.TypeOf((*encoding.BinaryUnmarshaler)(nil)).Elem()
+	typeOfJsonUnmarshaler   = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+)
@@ -0,0 +1,76 @@
+package structproto
+
+import (
+	"reflect"
+	"regexp"
+	"time"
+)
+
+var (
+	// sharedDefaultValueTypes are the pointer types whose values are
+	// immutable, the default values of them are shared rather than copied.
+	sharedDefaultValueTypes = map[reflect.Type]bool{
+		reflect.TypeOf((*time.Location)(nil)): true,
+		reflect.TypeOf((*regexp.Regexp)(nil)): true,
+	}
+)
+
+// cloneDefaultValue returns a deep copy of the default value v converted
+// at resolve time, so the bound structs do not share the slices, maps and
+// pointees of it. The v must be addressable and acyclic.
+func cloneDefaultValue(v reflect.Value) reflect.Value {
+	dst := reflect.New(v.Type()).Elem()
+	copyDefaultValue(dst, v)
+	return dst
+}
+
+func copyDefaultValue(dst, src reflect.Value) {
+	if sharedDefaultValueTypes[src.Type()] {
+		dst.Set(src)
+		return
+	}
+
+	switch src.Kind() {
+	case reflect.Ptr:
+		if !src.IsNil() {
+			p := reflect.New(src.Type().Elem())
+			copyDefaultValue(p.Elem(), src.Elem())
+			dst.Set(p)
+		}
+	case reflect.Interface:
+		if !src.IsNil() {
+			elem := reflect.New(src.Elem().Type()).Elem()
+			elem.Set(src.Elem())
+			dst.Set(cloneDefaultValue(elem))
+		}
+	case reflect.Slice:
+		if !src.IsNil() {
+			s := reflect.MakeSlice(src.Type(), src.Len(), src.Len())
+			for i := 0; i < src.Len(); i++ {
+				copyDefaultValue(s.Index(i), src.Index(i))
+			}
+			dst.Set(s)
+		}
+	case reflect.Array:
+		for i := 0; i < src.Len(); i++ {
+			copyDefaultValue(dst.Index(i), src.Index(i))
+		}
+	case reflect.Map:
+		if !src.IsNil() {
+			m := reflect.MakeMapWithSize(src.Type(), src.Len())
+			iter := src.MapRange()
+			for iter.Next() {
+				elem := reflect.New(src.Type().Elem()).Elem()
+				elem.Set(iter.Value())
+				m.SetMapIndex(iter.Key(), cloneDefaultValue(elem))
+			}
+			dst.Set(m)
+		}
+	case reflect.Struct:
+		for i := 0; i < src.NumField(); i++ {
+			copyDefaultValue(makeAccessible(dst.Field(i)), makeAccessible(src.Field(i)))
+		}
+	default:
+		dst.Set(src)
+	}
+}
@@ -161,3 +161,42 @@ func ExampleFieldValueMap() {
 	// DateOfBirth: "2020-05-05 00:00:00 +0000 UTC"
 	// Remark     : ""
 }
+
+func ExampleNew() {
+	type Character struct {
+		Name        string    `demo:"*NAME"`
+		Age         *int      `demo:"*AGE"`
+		Alias       []string  `demo:"ALIAS"`
+		DateOfBirth time.Time `demo:"DATE_OF_BIRTH;the character's birth of date"`
+		Remark      string    `demo:"REMARK;note the character's personal favor"`
+	}
+
+	prototype, err := structproto.New[Character](
+		&structproto.StructProtoResolveOption{
+			TagName: "demo",
+		})
+	if err != nil {
+		panic(err)
+	}
+
+	c, err := prototype.BindMap(map[string]interface{}{
+		"NAME":          "luffy",
+		"AGE":           "19",
+		"ALIAS":         "lucy",
+		"DATE_OF_BIRTH": "2020-05-05T00:00:00Z",
+	}, valuebinder.BuildStringBinder)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("Name       : %q\n", c.Name)
+	fmt.Printf("Age        : %d\n", *c.Age)
+	fmt.Printf("Alias      : %q\n", c.Alias)
+	fmt.Printf("DateOfBirth: %q\n", c.DateOfBirth)
+	fmt.Printf("Remark     : %q\n", c.Remark)
+	// Output:
+	// Name       : "luffy"
+	// Age        : 19
+	// Alias      : ["lucy"]
+	// DateOfBirth: "2020-05-05 00:00:00 +0000 UTC"
+	// Remark     : ""
+}
@@ -0,0 +1,191 @@
+package structproto
+
+import (
+	"reflect"
+	"sort"
+	"unsafe"
+)
+
+// fieldByIndexPath returns the nested field of v specified by field,
+// allocating every nil struct pointer it walks through.
+func fieldByIndexPath(v reflect.Value, field *FieldInfoImpl) reflect.Value {
+	for i, index := range field.indexPath {
+		if i > 0 {
+			if v.Kind() == reflect.Ptr {
+				if v.IsNil() {
+					v.Set(reflect.New(v.Type().Elem()))
+				}
+				v = v.Elem()
+			}
+		}
+		v = field.accessible(v.Field(index))
+	}
+	return v
+}
+
+// detachedFieldByIndexPath returns the nested field of v specified by field
+// like fieldByIndexPath, but the first nil struct pointer it walks through
+// is replaced by a detached value, which is assigned by commit. So the
+// pointers are allocated only if the field is bound successfully.
+func detachedFieldByIndexPath(v reflect.Value, field *FieldInfoImpl) (rv reflect.Value, commit func()) {
+	var owner, detached reflect.Value
+	for i, index := range field.indexPath {
+		if i > 0 {
+			if v.Kind() == reflect.Ptr {
+				if v.IsNil() {
+					if owner.IsValid() {
+						// inside the detached value already
+						v.Set(reflect.New(v.Type().Elem()))
+					} else {
+						owner, detached = v, reflect.New(v.Type().Elem())
+						v = detached
+					}
+				}
+				v = v.Elem()
+			}
+		}
+		v = field.accessible(v.Field(index))
+	}
+	if !owner.IsValid() {
+		return v, noCommit
+	}
+	return v, func() {
+		owner.Set(detached)
+	}
+}
+
+func noCommit() {}
+
+// lookupFieldByIndexPath returns the nested field of v specified by field,
+// it reports false if the path walks through a nil struct pointer.
+func lookupFieldByIndexPath(v reflect.Value, field *FieldInfoImpl) (reflect.Value, bool) {
+	for i, index := range field.indexPath {
+		if i > 0 {
+			if v.Kind() == reflect.Ptr {
+				if v.IsNil() {
+					return reflect.Value{}, false
+				}
+				v = v.Elem()
+			}
+		}
+		v = field.accessible(v.Field(index))
+	}
+	return v, true
+}
+
+// detachFieldByIndexPath copies the values of the non-nil pointers which
+// the path of field walks through and the field points to, so writing the
+// field of v does not reach the values shared with others. The detached
+// records the copied pointers to copy each of them once.
+func detachFieldByIndexPath(v reflect.Value, field *FieldInfoImpl, detached map[uintptr]bool) {
+	for i, index := range field.indexPath {
+		if i > 0 {
+			if v.Kind() == reflect.Ptr {
+				if v.IsNil() {
+					return
+				}
+				detachPointer(v, detached)
+				v = v.Elem()
+			}
+		}
+		v = field.accessible(v.Field(index))
+	}
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		detachPointer(v, detached)
+		v = v.Elem()
+	}
+}
+
+func detachPointer(v reflect.Value, detached map[uintptr]bool) {
+	if detached[v.Pointer()] {
+		return
+	}
+	copied := reflect.New(v.Type().Elem())
+	copied.Elem().Set(v.Elem())
+	v.Set(copied)
+	detached[copied.Pointer()] = true
+}
+
+func compareIndexPath(x, y []int) int {
+	for i := 0; i < len(x) && i < len(y); i++ {
+		if x[i] != y[i] {
+			return x[i] - y[i]
+		}
+	}
+	return len(x) - len(y)
+}
+
+// makeAccessible returns a settable alias of the unexported field v. The
+// v must be addressable.
+func makeAccessible(v reflect.Value) reflect.Value {
+	if v.CanSet() || !v.CanAddr() {
+		return v
+	}
+	return reflect.NewAt(v.Type(), unsafe.Pointer(v.UnsafeAddr())).Elem()
+}
+
+// lazyFieldAllocator resolves nested fields like fieldByIndexPath, but
+// nil struct pointers are replaced by detached values which are assigned
+// back by commit() only if they are not zero.
+type lazyFieldAllocator struct {
+	target  reflect.Value
+	pending []*pendingAllocation
+}
+
+type pendingAllocation struct {
+	path  []int
+	owner reflect.Value
+	value reflect.Value
+}
+
+func (a *lazyFieldAllocator) field(field *FieldInfoImpl) reflect.Value {
+	var (
+		v    = a.target
+		path = field.indexPath
+	)
+	for i, index := range path {
+		if i > 0 {
+			if v.Kind() == reflect.Ptr {
+				if v.IsNil() {
+					v = a.allocate(path[:i], v)
+				}
+				v = v.Elem()
+			}
+		}
+		v = field.accessible(v.Field(index))
+	}
+	return v
+}
+func (a *lazyFieldAllocator) allocate(path []int, owner reflect.Value) reflect.Value {
+	for _, p := range a.pending {
+		if compareIndexPath(p.path, path) == 0 {
+			return p.value
+		}
+	}
+
+	p := &pendingAllocation{
+		path:  path,
+		owner: owner,
+		value: reflect.New(owner.Type().Elem()),
+	}
+	a.pending = append(a.pending, p)
+	return p.value
+}
+
+func (a *lazyFieldAllocator) commit() {
+	if len(a.pending) == 0 {
+		return
+	}
+
+	// assign the deepest values first, so their owners can be checked
+	// whether they are still zero
+	sort.SliceStable(a.pending, func(i, j int) bool {
+		return len(a.pending[i].path) > len(a.pending[j].path)
+	})
+	for _, p := range a.pending {
+		if !p.value.Elem().IsZero() {
+			p.owner.Set(p.value)
+		}
+	}
+	a.pending = nil
+}
@@ -5,12 +5,29 @@ import "reflect"
 var _ FieldInfo = new(FieldInfoImpl)
 
 type FieldInfoImpl struct {
-	idName string
-	name   string
-	desc   string
-	index  int
-	flags  FieldFlagSet
-	tag    reflect.StructTag
+	idName    string
+	name      string
+	desc      string
+	index     int
+	indexPath []int
+	flags     FieldFlagSet
+	tag       reflect.StructTag
+	tagName   string
+
+	defaultValue *string
+	// boundDefaultValue is the defaultValue converted at resolve time,
+	// it is invalid if DefaultValueBindProvider provides no ValueBinder
+	boundDefaultValue reflect.Value
+	aliases           []string
+	attrs             map[string]string
+	// bindAttrs are the attributes passed to the AttrBinder, the attrs
+	// with the resolver defaults
+	bindAttrs    map[string]string
+	repeatedKeys RepeatedKeyPolicy
+
+	// unsafe indicates the field or one of its owners is unexported and
+	// bound by UnsafeBindUnexportedField policy
+	unsafe bool
 }
 
 // IDName implements FieldInfo.
@@ -28,11 +45,21 @@ func (f *FieldInfoImpl) Desc() string {
 	return f.desc
 }
 
-// Index implements FieldInfo.
+// Index implements FieldInfo. It returns the index of the field in the
+// struct which declares it, use IndexPath for the promoted or nested
+// fields.
 func (f *FieldInfoImpl) Index() int {
 	return f.index
 }
 
+// IndexPath implements FieldInfo. It returns the index sequence to reach
+// the field from the prototype target, see reflect.Value.FieldByIndex.
+func (f *FieldInfoImpl) IndexPath() []int {
+	path := make([]int, len(f.indexPath))
+	copy(path, f.indexPath)
+	return path
+}
+
 // FindFlag implements FieldInfo.
 func (f *FieldInfoImpl) FindFlag(predicate func(v string) bool) bool {
 	return f.flags.find(predicate)
@@ -43,11 +70,51 @@ func (f *FieldInfoImpl) HasFlag(v string) bool {
 	return f.flags.has(v)
 }
 
+// Flags implements FieldInfo.
+func (f *FieldInfoImpl) Flags() []string {
+	if f.flags.isEmpty() {
+		return nil
+	}
+	flags := make([]string, f.flags.len())
+	copy(flags, f.flags)
+	return flags
+}
+
+// Attr implements FieldInfo.
+func (f *FieldInfoImpl) Attr(key string) (string, bool) {
+	v, ok := f.attrs[key]
+	return v, ok
+}
+
 // Tag implements FieldInfo.
 func (f *FieldInfoImpl) Tag() reflect.StructTag {
 	return f.tag
 }
 
+// Default implements FieldInfo.
+func (f *FieldInfoImpl) Default() (string, bool) {
+	if f.defaultValue != nil {
+		return *f.defaultValue, true
+	}
+	return "", false
+}
+
+// Aliases implements FieldInfo.
+func (f *FieldInfoImpl) Aliases() []string {
+	if len(f.aliases) == 0 {
+		return nil
+	}
+	aliases := make([]string, len(f.aliases))
+	copy(aliases, f.aliases)
+	return aliases
+}
+
+// TagName implements FieldInfo. It returns the name of the tag which the
+// field is resolved from, or empty if it is not resolved from any tag.
+func (f *FieldInfoImpl) TagName() string {
+	return f.tagName
+}
+
 func (f *FieldInfoImpl) appendFlags(values ...string) {
 	if len(values) == 0 {
 		return
@@ -60,3 +127,22 @@ func (f *FieldInfoImpl) appendFlags(values ...string) {
 		f.flags.append(v)
 	}
 }
+
+// withAttrs specializes binder by the attributes of the field if it is an
+// AttrBinder.
+func (f *FieldInfoImpl) withAttrs(binder ValueBinder) ValueBinder {
+	if len(f.bindAttrs) == 0 {
+		return binder
+	}
+	if binder, ok := binder.(AttrBinder); ok {
+		return binder.WithAttrs(f.bindAttrs)
+	}
+	return binder
+}
+
+func (f *FieldInfoImpl) accessible(v reflect.Value) reflect.Value {
+	if f.unsafe {
+		return makeAccessible(v)
+	}
+	return v
+}
@@ -0,0 +1,79 @@
+package structproto
+
+import "reflect"
+
+type fieldScope struct {
+	indexPath []int
+	idName    string
+	name      string
+	depth     int
+	unsafe    bool
+	visiting  []reflect.Type
+}
+
+func (scope *fieldScope) indexPathOf(index int) []int {
+	path := make([]int, len(scope.indexPath)+1)
+	copy(path, scope.indexPath)
+	path[len(scope.indexPath)] = index
+	return path
+}
+
+func (scope *fieldScope) namesOf(names []string) []string {
+	if len(names) == 0 {
+		return nil
+	}
+	container := make([]string, len(names))
+	for i, name := range names {
+		container[i] = scope.name + name
+	}
+	return container
+}
+
+func (scope *fieldScope) isVisiting(t reflect.Type) bool {
+	for _, v := range scope.visiting {
+		if v == t {
+			return true
+		}
+	}
+	return false
+}
+
+func (scope *fieldScope) visit(t reflect.Type) *fieldScope {
+	visiting := make([]reflect.Type, len(scope.visiting)+1)
+	copy(visiting, scope.visiting)
+	visiting[len(scope.visiting)] = t
+
+	cloned := *scope
+	cloned.visiting = visiting
+	return &cloned
+}
+
+func (scope *fieldScope) embed(index int, unsafe bool) *fieldScope {
+	return &fieldScope{
+		indexPath: scope.indexPathOf(index),
+		idName:    scope.idName,
+		name:      scope.name,
+		depth:     scope.depth + 1,
+		unsafe:    scope.unsafe || unsafe,
+		visiting:  scope.visiting,
+	}
+}
+
+func (scope *fieldScope) nest(field *FieldInfoImpl, t reflect.Type) *fieldScope {
+	nested := &fieldScope{
+		indexPath: field.indexPath,
+		idName:    field.idName + NestedFieldNameSeparator,
+		name:      field.name + NestedFieldNameSeparator,
+		depth:     scope.depth,
+		unsafe:    field.unsafe,
+		visiting:  scope.visiting,
+	}
+	return nested.visit(t)
+}
+
+type fieldCandidate struct {
+	*FieldInfoImpl
+
+	depth  int
+	tagged bool
+}
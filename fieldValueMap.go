@@ -1,16 +1,43 @@
 package structproto
 
-var _ Iterator = new(FieldValueMap)
+import "context"
+
+var (
+	_ ContextIterator = new(FieldValueMap)
+	_ SeqIterator     = new(FieldValueMap)
+)
 
 type FieldValueMap map[string]interface{}
 
+// Iterate implements Iterator. The consumer must receive all values,
+// otherwise use IterateContext.
 func (values FieldValueMap) Iterate() <-chan FieldValueEntity {
+	return values.IterateContext(context.Background())
+}
+
+// IterateContext implements ContextIterator.
+func (values FieldValueMap) IterateContext(ctx context.Context) <-chan FieldValueEntity {
 	c := make(chan FieldValueEntity, 1)
 	go func() {
+		defer close(c)
 		for k, v := range values {
-			c <- FieldValueEntity{k, v}
+			select {
+			case c <- FieldValueEntity{k, v}:
+			case <-ctx.Done():
+				return
+			}
 		}
-		close(c)
 	}()
 	return c
 }
+
+// Seq implements SeqIterator.
+func (values FieldValueMap) Seq() FieldValueSeq {
+	return func(yield func(FieldValueEntity) bool) {
+		for k, v := range values {
+			if !yield(FieldValueEntity{k, v}) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package structproto
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	// ExactKeyMatching matches the keys and field names exactly.
+	ExactKeyMatching KeyMatching = iota
+	// CaseInsensitiveKeyMatching matches the keys and field names with
+	// case-insensitivity, e.g. "db_host" and "DB_HOST".
+	CaseInsensitiveKeyMatching
+	// NormalizedKeyMatching matches the keys and field names with
+	// case-insensitivity and ignores the '_', '-' and '.' characters,
+	// e.g. "db_host", "DB-HOST" and "DbHost".
+	NormalizedKeyMatching
+)
+
+type KeyMatching int
+
+func (m KeyMatching) normalize(key string) string {
+	switch m {
+	case CaseInsensitiveKeyMatching:
+		return strings.ToLower(key)
+	case NormalizedKeyMatching:
+		var sb strings.Builder
+		sb.Grow(len(key))
+		for _, ch := range key {
+			switch ch {
+			case '_', '-', '.':
+				continue
+			}
+			sb.WriteRune(unicode.ToLower(ch))
+		}
+		return sb.String()
+	}
+	return key
+}
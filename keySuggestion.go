@@ -0,0 +1,78 @@
+package structproto
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	maxKeySuggestions = 3
+)
+
+// suggestKeys returns the candidates close to key in edit distance, the
+// closest first. Both sides are normalized by matching as the binding
+// does, and compared case-insensitively as well.
+func suggestKeys(key string, candidates []string, matching KeyMatching) []string {
+	type suggestion struct {
+		name     string
+		distance int
+	}
+
+	var (
+		suggestions []suggestion
+		normalize   = func(v string) string {
+			return strings.ToLower(matching.normalize(v))
+		}
+		normalized = normalize(key)
+		// allow about one typo in every three characters
+		threshold = utf8.RuneCountInString(normalized)/3 + 1
+	)
+	for _, name := range candidates {
+		d := editDistance(normalized, normalize(name))
+		if d <= threshold {
+			suggestions = append(suggestions, suggestion{name, d})
+		}
+	}
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return suggestions[i].distance < suggestions[j].distance
+	})
+
+	var names []string
+	for i := 0; i < len(suggestions) && i < maxKeySuggestions; i++ {
+		names = append(names, suggestions[i].name)
+	}
+	return names
+}
+
+// editDistance returns the Damerau-Levenshtein distance (the optimal
+// string alignment variant) between x and y.
+func editDistance(x, y string) int {
+	var (
+		a = []rune(x)
+		b = []rune(y)
+
+		prev2 = make([]int, len(b)+1)
+		prev  = make([]int, len(b)+1)
+		curr  = make([]int, len(b)+1)
+	)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			// transposition
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				curr[j] = min(curr[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, curr = prev, curr, prev2
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,31 @@
+package structproto
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSuggestKeys(t *testing.T) {
+	var candidates = []string{"DB_HOST", "DB_USERNAME", "HOSTNAME"}
+
+	var cases = []struct {
+		key      string
+		matching KeyMatching
+		expected []string
+	}{
+		{"DB_HOTS", ExactKeyMatching, []string{"DB_HOST"}},
+		{"db_host", ExactKeyMatching, []string{"DB_HOST"}},
+		{"db_hots", CaseInsensitiveKeyMatching, []string{"DB_HOST"}},
+		// "db-hots" is "dbhots" to the normalized matching, one typo of "dbhost"
+		{"db-hots", NormalizedKeyMatching, []string{"DB_HOST"}},
+		{"D.B.U.S.E.R.N.A.M", NormalizedKeyMatching, []string{"DB_USERNAME"}},
+		{"D.B.U.S.E.R.N.A.M", ExactKeyMatching, nil},
+		{"UNRELATED", NormalizedKeyMatching, nil},
+	}
+	for _, c := range cases {
+		v := suggestKeys(c.key, candidates, c.matching)
+		if !reflect.DeepEqual(c.expected, v) {
+			t.Errorf("assert 'suggestKeys(%q, %v)':: expected '%v', got '%v'", c.key, c.matching, c.expected, v)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package naming
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/Bofry/structproto/common"
+)
+
+var (
+	_ common.NamingStrategy = SnakeCase
+	_ common.NamingStrategy = ScreamingSnakeCase
+	_ common.NamingStrategy = KebabCase
+	_ common.NamingStrategy = CamelCase
+	_ common.NamingStrategy = LowerCase
+)
+
+// SnakeCase converts "HTTPPort" to "http_port".
+func SnakeCase(name string) string {
+	return join(splitWords(name), "_", strings.ToLower)
+}
+
+// ScreamingSnakeCase converts "HTTPPort" to "HTTP_PORT".
+func ScreamingSnakeCase(name string) string {
+	return join(splitWords(name), "_", strings.ToUpper)
+}
+
+// KebabCase converts "HTTPPort" to "http-port".
+func KebabCase(name string) string {
+	return join(splitWords(name), "-", strings.ToLower)
+}
+
+// CamelCase converts "HTTPPort" to "httpPort".
+func CamelCase(name string) string {
+	words := splitWords(name)
+	for i, word := range words {
+		if i == 0 {
+			words[i] = strings.ToLower(word)
+		} else {
+			first, size := utf8.DecodeRuneInString(word)
+			words[i] = string(unicode.ToUpper(first)) + strings.ToLower(word[size:])
+		}
+	}
+	return strings.Join(words, "")
+}
+
+// LowerCase converts "HTTPPort" to "httpport".
+func LowerCase(name string) string {
+	return strings.ToLower(name)
+}
+
+func join(words []string, sep string, fn func(string) string) string {
+	for i, word := range words {
+		words[i] = fn(word)
+	}
+	return strings.Join(words, sep)
+}
+
+// splitWords splits name into words by '_', '-', case changes and the
+// acronyms, e.g. "HTTPPort" into "HTTP" and "Port", "UserID" into "User"
+// and "ID". The digits belong to the preceding word.
+func splitWords(name string) []string {
+	var (
+		words []string
+		runes = []rune(name)
+		start = 0
+	)
+
+	flush := func(end int) {
+		if end > start {
+			words = append(words, string(runes[start:end]))
+		}
+	}
+
+	for i := 0; i < len(runes); i++ {
+		ch := runes[i]
+		if ch == '_' || ch == '-' || ch == '.' || unicode.IsSpace(ch) {
+			flush(i)
+			start = i + 1
+			continue
+		}
+		if i == start || !unicode.IsUpper(ch) {
+			continue
+		}
+
+		prev := runes[i-1]
+		switch {
+		case unicode.IsLower(prev) || unicode.IsDigit(prev):
+			// "maxConns" => "max" | "Conns"
+			flush(i)
+			start = i
+		case unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1]):
+			// "HTTPPort" => "HTTP" | "Port"
+			flush(i)
+			start = i
+		}
+	}
+	flush(len(runes))
+	return words
+}
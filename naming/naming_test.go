@@ -0,0 +1,43 @@
+package naming
+
+import "testing"
+
+func TestNamingStrategies(t *testing.T) {
+	cases := []struct {
+		name               string
+		snakeCase          string
+		screamingSnakeCase string
+		kebabCase          string
+		camelCase          string
+		lowerCase          string
+	}{
+		{"MaxConns", "max_conns", "MAX_CONNS", "max-conns", "maxConns", "maxconns"},
+		{"HTTPPort", "http_port", "HTTP_PORT", "http-port", "httpPort", "httpport"},
+		{"UserID", "user_id", "USER_ID", "user-id", "userId", "userid"},
+		{"ID", "id", "ID", "id", "id", "id"},
+		{"DBHost2", "db_host2", "DB_HOST2", "db-host2", "dbHost2", "dbhost2"},
+		{"Retry3Times", "retry3_times", "RETRY3_TIMES", "retry3-times", "retry3Times", "retry3times"},
+		{"Already_Snake", "already_snake", "ALREADY_SNAKE", "already-snake", "alreadySnake", "already_snake"},
+		{"name", "name", "NAME", "name", "name", "name"},
+		{"MaxÉlan", "max_élan", "MAX_ÉLAN", "max-élan", "maxÉlan", "maxélan"},
+		{"user_ñame", "user_ñame", "USER_ÑAME", "user-ñame", "userÑame", "user_ñame"},
+	}
+
+	for _, c := range cases {
+		if v := SnakeCase(c.name); v != c.snakeCase {
+			t.Errorf("assert SnakeCase(%q):: expected '%s', got '%s'", c.name, c.snakeCase, v)
+		}
+		if v := ScreamingSnakeCase(c.name); v != c.screamingSnakeCase {
+			t.Errorf("assert ScreamingSnakeCase(%q):: expected '%s', got '%s'", c.name, c.screamingSnakeCase, v)
+		}
+		if v := KebabCase(c.name); v != c.kebabCase {
+			t.Errorf("assert KebabCase(%q):: expected '%s', got '%s'", c.name, c.kebabCase, v)
+		}
+		if v := CamelCase(c.name); v != c.camelCase {
+			t.Errorf("assert CamelCase(%q):: expected '%s', got '%s'", c.name, c.camelCase, v)
+		}
+		if v := LowerCase(c.name); v != c.lowerCase {
+			t.Errorf("assert LowerCase(%q):: expected '%s', got '%s'", c.name, c.lowerCase, v)
+		}
+	}
+}
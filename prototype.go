@@ -0,0 +1,130 @@
+package structproto
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/Bofry/structproto/reflecting"
+)
+
+// Prototype is the typed variant of Struct. It resolves the struct type T
+// once, and binds the values into a fresh T on each call. The T must be a
+// struct or a pointer to struct.
+type Prototype[T any] struct {
+	resolver *StructProtoResolver
+	schema   *structSchema
+}
+
+// New resolves the struct type T with option.
+func New[T any](option *StructProtoResolveOption) (*Prototype[T], error) {
+	p := &Prototype[T]{
+		resolver: NewStructProtoResolver(option),
+	}
+
+	// keep the field layout of T, so the bindings neither look up the
+	// cache nor resolve it again
+	prototype, err := p.resolver.Resolve((*T)(nil))
+	if err != nil {
+		return nil, err
+	}
+	p.schema = prototype.structSchema
+	return p, nil
+}
+
+// Into resolves the struct type T with option and returns the prototype
+// of target.
+func Into[T any](target *T, option *StructProtoResolveOption) (*Struct, error) {
+	if target == nil {
+		panic("specified argument 'target' cannot be nil")
+	}
+
+	r := NewStructProtoResolver(option)
+	return r.Resolve(allocate(target))
+}
+
+// Into returns the prototype of target.
+func (p *Prototype[T]) Into(target *T) (*Struct, error) {
+	if target == nil {
+		panic("specified argument 'target' cannot be nil")
+	}
+
+	rv := reflect.ValueOf(allocate(target)).Elem()
+	if rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+	return p.resolver.newStruct(rv, p.schema), nil
+}
+
+func (p *Prototype[T]) Bind(binder StructBinder) (T, error) {
+	return p.bind(func(prototype *Struct) error {
+		return prototype.Bind(binder)
+	})
+}
+
+func (p *Prototype[T]) BindMap(values map[string]interface{}, buildValueBinder ValueBindProvider) (T, error) {
+	return p.bind(func(prototype *Struct) error {
+		return prototype.BindMap(values, buildValueBinder)
+	})
+}
+
+func (p *Prototype[T]) BindIterator(iterator Iterator, buildValueBinder ValueBindProvider) (T, error) {
+	return p.bind(func(prototype *Struct) error {
+		return prototype.BindIterator(iterator, buildValueBinder)
+	})
+}
+
+func (p *Prototype[T]) BindIteratorContext(ctx context.Context, iterator Iterator, buildValueBinder ValueBindProvider) (T, error) {
+	return p.bind(func(prototype *Struct) error {
+		return prototype.BindIteratorContext(ctx, iterator, buildValueBinder)
+	})
+}
+
+func (p *Prototype[T]) BindFields(values []FieldValueEntity, buildValueBinder ValueBindProvider) (T, error) {
+	return p.bind(func(prototype *Struct) error {
+		return prototype.BindFields(values, buildValueBinder)
+	})
+}
+
+func (p *Prototype[T]) BindSeq(seq FieldValueSeq, buildValueBinder ValueBindProvider) (T, error) {
+	return p.bind(func(prototype *Struct) error {
+		return prototype.BindSeq(seq, buildValueBinder)
+	})
+}
+
+func (p *Prototype[T]) BindChan(iterator <-chan FieldValueEntity, buildValueBinder ValueBindProvider) (T, error) {
+	return p.bind(func(prototype *Struct) error {
+		return prototype.BindChan(iterator, buildValueBinder)
+	})
+}
+
+func (p *Prototype[T]) BindChanContext(ctx context.Context, iterator <-chan FieldValueEntity, buildValueBinder ValueBindProvider) (T, error) {
+	return p.bind(func(prototype *Struct) error {
+		return prototype.BindChanContext(ctx, iterator, buildValueBinder)
+	})
+}
+
+func (p *Prototype[T]) bind(proc func(prototype *Struct) error) (T, error) {
+	var (
+		target T
+		zero   T
+	)
+
+	prototype, err := p.Into(&target)
+	if err != nil {
+		return zero, err
+	}
+	if err = proc(prototype); err != nil {
+		return zero, err
+	}
+	return target, nil
+}
+
+// allocate assigns a new value to *target if T is a pointer type and
+// *target is nil.
+func allocate[T any](target *T) *T {
+	rv := reflect.ValueOf(target).Elem()
+	if rv.Kind() == reflect.Ptr {
+		reflecting.AssignZero(rv)
+	}
+	return target
+}
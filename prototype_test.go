@@ -0,0 +1,175 @@
+package structproto_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Bofry/structproto"
+	"github.com/Bofry/structproto/valuebinder"
+	"go.openly.dev/pointy"
+)
+
+type character struct {
+	Name        string    `demo:"*NAME"`
+	Age         *int      `demo:"*AGE"`
+	Alias       []string  `demo:"ALIAS"`
+	DateOfBirth time.Time `demo:"DATE_OF_BIRTH;the character's birth of date"`
+}
+
+func TestPrototype_BindMap(t *testing.T) {
+	prototype, err := structproto.New[character](&structproto.StructProtoResolveOption{
+		TagName: "demo",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	values := map[string]interface{}{
+		"NAME":          "luffy",
+		"AGE":           "19",
+		"ALIAS":         "lucy",
+		"DATE_OF_BIRTH": "2020-05-05T00:00:00Z",
+	}
+	first, err := prototype.BindMap(values, valuebinder.BuildStringBinder)
+	if err != nil {
+		t.Error(err)
+	}
+	second, err := prototype.BindMap(values, valuebinder.BuildStringBinder)
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected := character{
+		Name:        "luffy",
+		Age:         pointy.Int(19),
+		Alias:       []string{"lucy"},
+		DateOfBirth: time.Date(2020, 5, 5, 0, 0, 0, 0, time.UTC),
+	}
+	if !reflect.DeepEqual(expected, first) {
+		t.Errorf("assert:: expected '%+v', got '%+v'", expected, first)
+	}
+	if first.Age == second.Age {
+		t.Errorf("assert:: expected each call binds a fresh value")
+	}
+}
+
+func TestPrototype_BindFields_WithPointerType(t *testing.T) {
+	prototype, err := structproto.New[*character](&structproto.StructProtoResolveOption{
+		TagName: "demo",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v, err := prototype.BindFields([]structproto.FieldValueEntity{
+		{Field: "NAME", Value: "luffy"},
+		{Field: "AGE", Value: "19"},
+	}, valuebinder.BuildStringBinder)
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected := &character{
+		Name: "luffy",
+		Age:  pointy.Int(19),
+	}
+	if !reflect.DeepEqual(expected, v) {
+		t.Errorf("assert:: expected '%+v', got '%+v'", expected, v)
+	}
+}
+
+func TestPrototype_BindMap_MissingRequiredField(t *testing.T) {
+	prototype, err := structproto.New[character](&structproto.StructProtoResolveOption{
+		TagName: "demo",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = prototype.BindMap(map[string]interface{}{
+		"NAME": "luffy",
+	}, valuebinder.BuildStringBinder)
+
+	var missingRequiredFieldError *structproto.MissingRequiredFieldError
+	if !errors.As(err, &missingRequiredFieldError) {
+		t.Fatalf("the error expected '%T', got '%T'", missingRequiredFieldError, err)
+	}
+	if missingRequiredFieldError.Field != "AGE" {
+		t.Errorf("assert 'MissingRequiredFieldError.Field':: expected '%v', got '%v'", "AGE", missingRequiredFieldError.Field)
+	}
+}
+
+func TestNew_WithNonStructType(t *testing.T) {
+	_, err := structproto.New[int](&structproto.StructProtoResolveOption{})
+	if err == nil {
+		t.Errorf("the 'New()' should throw error on non-struct type")
+	}
+}
+
+func TestInto(t *testing.T) {
+	var c *character
+
+	prototype, err := structproto.Into(&c, &structproto.StructProtoResolveOption{
+		TagName: "demo",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = prototype.BindMap(map[string]interface{}{
+		"NAME": "luffy",
+		"AGE":  "19",
+	}, valuebinder.BuildStringBinder)
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected := &character{
+		Name: "luffy",
+		Age:  pointy.Int(19),
+	}
+	if !reflect.DeepEqual(expected, c) {
+		t.Errorf("assert:: expected '%+v', got '%+v'", expected, c)
+	}
+}
+
+func TestPrototype_ResolvesOnce(t *testing.T) {
+	type model struct {
+		Name string
+		Port int
+	}
+
+	// the closure disables the cache, the prototype keeps its own layout
+	var resolved int
+	prototype, err := structproto.New[model](&structproto.StructProtoResolveOption{
+		NamingStrategy: func(name string) string {
+			resolved++
+			return strings.ToUpper(name)
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedResolved := resolved
+
+	values := map[string]interface{}{
+		"NAME": "luffy",
+		"PORT": "80",
+	}
+	for i := 0; i < 2; i++ {
+		structproto.PurgeCache()
+		v, err := prototype.BindMap(values, valuebinder.BuildStringBinder)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := model{Name: "luffy", Port: 80}
+		if v != expected {
+			t.Errorf("assert:: expected '%+v', got '%+v'", expected, v)
+		}
+	}
+	if resolved != expectedResolved {
+		t.Errorf("assert 'NamingStrategy' calls:: expected '%v', got '%v'", expectedResolved, resolved)
+	}
+}
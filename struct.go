@@ -1,6 +1,7 @@
 package structproto
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 )
@@ -8,8 +9,13 @@ import (
 type Struct struct {
 	target reflect.Value
 
-	fields         map[string]*FieldInfoImpl
-	requiredFields FieldFlagSet
+	*structSchema
+
+	onDeprecatedAlias    DeprecatedAliasHandler
+	collectBindingErrors bool
+	atomicBinding        bool
+	unknownKeys          UnknownKeyPolicy
+	onUnknownKeys        UnknownKeyHandler
 }
 
 func (s *Struct) Bind(binder StructBinder) error {
@@ -17,8 +23,15 @@ func (s *Struct) Bind(binder StructBinder) error {
 		panic("specified argument 'binder' cannot be nil")
 	}
 
+	return s.transact(func(s *Struct) error {
+		return s.bind(binder)
+	})
+}
+
+func (s *Struct) bind(binder StructBinder) error {
 	var (
 		context = buildStructProtoContext(s)
+		fields  = &lazyFieldAllocator{target: s.target}
 
 		err error
 	)
@@ -28,12 +41,14 @@ func (s *Struct) Bind(binder StructBinder) error {
 	}
 
 	// bind all fields
-	for _, field := range s.fields {
-		err := binder.Bind(field, s.target.Field(field.index))
+	for _, field := range s.orderedFields {
+		err := binder.Bind(field, fields.field(field))
 		if err != nil {
 			return err
 		}
 	}
+	// assign the nested struct pointers which have been bound
+	fields.commit()
 
 	if err = binder.Deinit(context); err != nil {
 		return err
@@ -47,10 +62,18 @@ func (s *Struct) BindMap(values map[string]interface{}, buildValueBinder ValueBi
 		return nil
 	}
 
-	return s.BindIterator(FieldValueMap(values), buildValueBinder)
+	return s.BindSeq(FieldValueMap(values).Seq(), buildValueBinder)
 }
 
 func (s *Struct) BindIterator(iterator Iterator, buildValueBinder ValueBindProvider) error {
+	return s.BindIteratorContext(context.Background(), iterator, buildValueBinder)
+}
+
+// BindIteratorContext binds the values of iterator until it is exhausted
+// or ctx is done. The iterator is consumed by its Seq if it implements
+// SeqIterator. Otherwise it is cancelled on return if it implements
+// ContextIterator, or the rest of its values are discarded in background.
+func (s *Struct) BindIteratorContext(ctx context.Context, iterator Iterator, buildValueBinder ValueBindProvider) error {
 	if s == nil {
 		return nil
 	}
@@ -58,7 +81,21 @@ func (s *Struct) BindIterator(iterator Iterator, buildValueBinder ValueBindProvi
 		return fmt.Errorf("missing ValueBinderProvider")
 	}
 
-	return s.BindChan(iterator.Iterate(), buildValueBinder)
+	if it, ok := iterator.(SeqIterator); ok {
+		return s.bindSeq(ctx, it.Seq(), buildValueBinder)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var c <-chan FieldValueEntity
+	if it, ok := iterator.(ContextIterator); ok {
+		c = it.IterateContext(ctx)
+	} else {
+		c = iterator.Iterate()
+		defer drain(c)
+	}
+	return s.BindChanContext(ctx, c, buildValueBinder)
 }
 
 func (s *Struct) BindFields(values []FieldValueEntity, buildValueBinder ValueBindProvider) error {
@@ -68,73 +105,93 @@ func (s *Struct) BindFields(values []FieldValueEntity, buildValueBinder ValueBin
 	if buildValueBinder == nil {
 		return fmt.Errorf("missing ValueBinderProvider")
 	}
-	var requiredFields = s.requiredFields.clone()
 
-	// mapping values
-	for _, v := range values {
-		field, val := v.Field, v.Value
-		if val != nil {
-			binder := s.makeFieldBinder(s.target, field, buildValueBinder)
-			if binder != nil {
-				err := binder.Bind(val)
-				if err != nil {
-					return &FieldBindingError{field, val, err}
-				}
+	return s.transact(func(s *Struct) error {
+		var binding = s.beginBinding(buildValueBinder)
 
-				index := requiredFields.indexOf(field)
-				if index != -1 {
-					// eliminate the field from slice if found
-					requiredFields.removeIndex(index)
-				}
+		// mapping values
+		for _, v := range values {
+			err := binding.bind(v)
+			if err != nil {
+				return err
 			}
 		}
-	}
+		return binding.end()
+	})
+}
 
-	// check if the requiredFields still have fields don't be set
-	if !requiredFields.isEmpty() {
-		field, _ := requiredFields.get(0)
-		return &MissingRequiredFieldError{field, nil}
+// BindSeq binds the values of seq in the calling goroutine.
+func (s *Struct) BindSeq(seq FieldValueSeq, buildValueBinder ValueBindProvider) error {
+	if s == nil {
+		return nil
+	}
+	if buildValueBinder == nil {
+		return fmt.Errorf("missing ValueBinderProvider")
 	}
 
-	return nil
+	return s.bindSeq(context.Background(), seq, buildValueBinder)
+}
+
+func (s *Struct) bindSeq(ctx context.Context, seq FieldValueSeq, buildValueBinder ValueBindProvider) error {
+	return s.transact(func(s *Struct) error {
+		var (
+			binding = s.beginBinding(buildValueBinder)
+
+			err error
+		)
+
+		// mapping values
+		seq(func(v FieldValueEntity) bool {
+			if err = ctx.Err(); err != nil {
+				return false
+			}
+			err = binding.bind(v)
+			return err == nil
+		})
+		if err != nil {
+			return err
+		}
+		return binding.end()
+	})
 }
 
 func (s *Struct) BindChan(iterator <-chan FieldValueEntity, buildValueBinder ValueBindProvider) error {
+	return s.BindChanContext(context.Background(), iterator, buildValueBinder)
+}
+
+// BindChanContext binds the values received from iterator until it is
+// closed or ctx is done, the ctx.Err() is returned in the latter case.
+func (s *Struct) BindChanContext(ctx context.Context, iterator <-chan FieldValueEntity, buildValueBinder ValueBindProvider) error {
 	if s == nil {
 		return nil
 	}
 	if buildValueBinder == nil {
 		return fmt.Errorf("missing ValueBinderProvider")
 	}
-	var requiredFields = s.requiredFields.clone()
 
-	// mapping values
-	for v := range iterator {
-		field, val := v.Field, v.Value
-		if val != nil {
-			binder := s.makeFieldBinder(s.target, field, buildValueBinder)
-			if binder != nil {
-				err := binder.Bind(val)
-				if err != nil {
-					return &FieldBindingError{field, val, err}
-				}
+	return s.transact(func(s *Struct) error {
+		var binding = s.beginBinding(buildValueBinder)
 
-				index := requiredFields.indexOf(field)
-				if index != -1 {
-					// eliminate the field from slice if found
-					requiredFields.removeIndex(index)
+		// mapping values
+		for {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case v, ok := <-iterator:
+				if !ok {
+					// a ContextIterator closes iterator when ctx is done
+					if err := ctx.Err(); err != nil {
+						return err
+					}
+					return binding.end()
+				}
+				err := binding.bind(v)
+				if err != nil {
+					return err
 				}
 			}
 		}
-	}
-
-	// check if the requiredFields still have fields don't be set
-	if !requiredFields.isEmpty() {
-		field, _ := requiredFields.get(0)
-		return &MissingRequiredFieldError{field, nil}
-	}
-
-	return nil
+	})
 }
 
 func (s *Struct) Map(mapper StructMapper) error {
@@ -145,29 +202,81 @@ func (s *Struct) Map(mapper StructMapper) error {
 }
 
 func (s *Struct) Visit(visitor StructVisitor) {
-	for name, info := range s.fields {
-		elem := s.target.Field(info.index)
-		visitor(name, elem, info)
+	for _, info := range s.orderedFields {
+		elem, ok := lookupFieldByIndexPath(s.target, info)
+		if !ok {
+			elem = reflect.Zero(s.typ.FieldByIndex(info.indexPath).Type)
+		}
+		visitor(info.name, elem, info)
 	}
 }
 
-func (s *Struct) makeFieldBinder(rv reflect.Value, name string, buildValueBinder ValueBindProvider) ValueBinder {
-	if f, ok := s.fields[name]; ok {
-		binder := buildValueBinder(rv.Field(f.index))
-		return binder
+// drain discards the rest values of c in background, so the goroutine
+// feeds c can run to the end.
+func drain(c <-chan FieldValueEntity) {
+	go func() {
+		for range c {
+		}
+	}()
+}
+
+// transact calls fn with s, or with a shadow of s which is assigned back
+// to the target only if fn succeeds when atomicBinding is set.
+func (s *Struct) transact(fn func(s *Struct) error) error {
+	if !s.atomicBinding {
+		return fn(s)
+	}
+
+	shadow := s.shadow()
+	err := fn(shadow)
+	if err != nil {
+		return err
 	}
+	s.target.Set(shadow.target)
 	return nil
 }
 
-func makeStruct(value reflect.Value) *Struct {
-	var expectedFields int
-	if value.Kind() == reflect.Struct {
-		expectedFields = value.Type().NumField()
+// shadow returns a copy of s which binds to a copy of the target, the
+// pointers on the fields are copied as well to keep the original intact.
+func (s *Struct) shadow() *Struct {
+	var (
+		target   = reflect.New(s.typ).Elem()
+		detached = make(map[uintptr]bool)
+	)
+	target.Set(s.target)
+	for _, field := range s.orderedFields {
+		detachFieldByIndexPath(target, field, detached)
 	}
+	if s.remainField != nil {
+		// the map is shared with the target, write into a copy of it
+		rv, ok := lookupFieldByIndexPath(target, s.remainField)
+		if ok && !rv.IsNil() {
+			copied := reflect.MakeMapWithSize(rv.Type(), rv.Len())
+			iter := rv.MapRange()
+			for iter.Next() {
+				copied.SetMapIndex(iter.Key(), iter.Value())
+			}
+			rv.Set(copied)
+		}
+	}
+
+	shadow := *s
+	shadow.target = target
+	return &shadow
+}
+
+// makeFieldBinder returns the ValueBinder of field, the commit assigns the
+// nil struct pointers on the path of field allocated for it and should be
+// called after the value is bound.
+func (s *Struct) makeFieldBinder(rv reflect.Value, field *FieldInfoImpl, buildValueBinder ValueBindProvider) (binder ValueBinder, commit func()) {
+	rv, commit = detachedFieldByIndexPath(rv, field)
+	return field.withAttrs(buildValueBinder(rv)), commit
+}
 
+func makeStruct(value reflect.Value, schema *structSchema) *Struct {
 	prototype := Struct{
-		target: value,
-		fields: make(map[string]*FieldInfoImpl, expectedFields),
+		target:       value,
+		structSchema: schema,
 	}
 	return &prototype
 }
@@ -0,0 +1,346 @@
+package structproto
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// structBinding keeps the bookkeeping of a single Bind* call on Struct.
+type structBinding struct {
+	prototype        *Struct
+	buildValueBinder ValueBindProvider
+
+	requiredFields *FieldFlagSet
+	defaultFields  *FieldFlagSet
+	// the alias of the last bound value on the fields have aliases
+	boundAliases map[*FieldInfoImpl]int
+	// the collected errors if Struct.collectBindingErrors is set
+	errors []fieldError
+	// the received keys which match no field
+	unknownKeys []string
+	// the keys have been bound on the fields care about repeated keys
+	boundKeys map[fieldKey]bool
+}
+
+type fieldError struct {
+	field *FieldInfoImpl
+	err   error
+}
+
+func (s *Struct) beginBinding(buildValueBinder ValueBindProvider) *structBinding {
+	return &structBinding{
+		prototype:        s,
+		buildValueBinder: buildValueBinder,
+		requiredFields:   s.requiredFields.clone(),
+		defaultFields:    s.defaultFields.clone(),
+	}
+}
+
+func (b *structBinding) bind(entity FieldValueEntity) error {
+	field, val := entity.Field, entity.Value
+
+	s := b.prototype
+	key, ok := s.lookupFieldKey(field)
+	if !ok {
+		if s.remainField != nil {
+			return b.bindRemain(field, val)
+		}
+		b.markUnknown(field)
+		return nil
+	}
+	if val == nil {
+		return nil
+	}
+	info := key.field
+
+	if len(info.aliases) > 0 {
+		accepted, err := b.acceptAlias(key)
+		if err != nil {
+			return b.fail(info, &FieldBindingError{field, val, err})
+		}
+		if !accepted {
+			return nil
+		}
+	}
+
+	if info.repeatedKeys != LastKeyWins && b.boundKeys[key] {
+		switch info.repeatedKeys {
+		case FirstKeyWins:
+			return nil
+		case RejectRepeatedKey:
+			return b.fail(info, &FieldBindingError{field, val, fmt.Errorf("repeated key '%s'", field)})
+		case AccumulateRepeatedKey:
+			return b.accumulate(key, field, val)
+		}
+	}
+
+	binder, commit := s.makeFieldBinder(s.target, info, b.buildValueBinder)
+	if binder != nil {
+		err := binder.Bind(val)
+		if err != nil {
+			return b.fail(info, &FieldBindingError{field, val, err})
+		}
+		commit()
+		b.markBound(info.name)
+
+		if info.repeatedKeys != LastKeyWins {
+			b.markKeyBound(key)
+		}
+
+		if len(info.aliases) > 0 {
+			b.markAliasBound(key)
+			b.notifyDeprecatedAlias(key)
+		}
+	}
+	return nil
+}
+
+// accumulate appends the elements bound from val to the slice field of key.
+func (b *structBinding) accumulate(key fieldKey, field string, val interface{}) error {
+	var (
+		s          = b.prototype
+		rv, commit = detachedFieldByIndexPath(s.target, key.field)
+		elements   = reflect.New(rv.Type()).Elem()
+		binder     = key.field.withAttrs(b.buildValueBinder(elements))
+	)
+	if binder == nil {
+		return nil
+	}
+	err := binder.Bind(val)
+	if err != nil {
+		return b.fail(key.field, &FieldBindingError{field, val, err})
+	}
+	rv.Set(reflect.AppendSlice(rv, elements))
+	commit()
+	b.notifyDeprecatedAlias(key)
+	return nil
+}
+
+// bindRemain stores the value of the key matches no field into the field
+// with RemainFlag, the value is converted by the ValueBindProvider.
+func (b *structBinding) bindRemain(key string, val interface{}) error {
+	if val == nil {
+		return nil
+	}
+
+	var (
+		s          = b.prototype
+		info       = s.remainField
+		rv, commit = detachedFieldByIndexPath(s.target, info)
+		elem       = reflect.New(rv.Type().Elem()).Elem()
+		binder     = info.withAttrs(b.buildValueBinder(elem))
+	)
+	if binder == nil {
+		return nil
+	}
+	err := binder.Bind(val)
+	if err != nil {
+		return b.fail(info, &FieldBindingError{key, val, err})
+	}
+	if rv.IsNil() {
+		rv.Set(reflect.MakeMap(rv.Type()))
+	}
+	rv.SetMapIndex(reflect.ValueOf(key).Convert(rv.Type().Key()), elem)
+	commit()
+	return nil
+}
+
+func (b *structBinding) end() error {
+	s := b.prototype
+
+	// bind default values on the fields which receive nothing
+	for _, field := range b.defaultFields.toArray() {
+		info := s.fields[field]
+		if b.hasFailed(info) {
+			// keep the failure rather than hiding it by the default value
+			continue
+		}
+		if info.boundDefaultValue.IsValid() {
+			rv, commit := detachedFieldByIndexPath(s.target, info)
+			rv.Set(cloneDefaultValue(info.boundDefaultValue))
+			commit()
+			b.removeRequired(field)
+			continue
+		}
+
+		// the default value was not converted at resolve time
+		val, _ := info.Default()
+		binder, commit := s.makeFieldBinder(s.target, info, b.buildValueBinder)
+		if binder != nil {
+			err := binder.Bind(val)
+			if err != nil {
+				err = b.fail(info, &FieldBindingError{field, val, err})
+				if err != nil {
+					return err
+				}
+			} else {
+				commit()
+			}
+			b.removeRequired(field)
+		}
+	}
+
+	// report the unknown keys ahead of the missing fields, since they are
+	// likely the misspelled names of the missing ones
+	if err := b.checkUnknownKeys(); err != nil {
+		return err
+	}
+
+	// check if the requiredFields still have fields don't be set
+	if !b.requiredFields.isEmpty() {
+		if !s.collectBindingErrors {
+			field := s.firstMissingField(b.requiredFields)
+			return &MissingRequiredFieldError{field, nil}
+		}
+		for _, field := range s.missingFields(b.requiredFields) {
+			info := s.fields[field]
+			if !b.hasFailed(info) {
+				b.errors = append(b.errors, fieldError{info, &MissingRequiredFieldError{field, nil}})
+			}
+		}
+	}
+	return b.collectedErrors()
+}
+
+func (b *structBinding) markUnknown(key string) {
+	if b.prototype.unknownKeys == IgnoreUnknownKey {
+		return
+	}
+	for _, k := range b.unknownKeys {
+		if k == key {
+			return
+		}
+	}
+	b.unknownKeys = append(b.unknownKeys, key)
+}
+
+func (b *structBinding) checkUnknownKeys() error {
+	if len(b.unknownKeys) == 0 {
+		return nil
+	}
+
+	var (
+		s     = b.prototype
+		names = s.names()
+		err   = &UnknownKeyError{
+			Keys: make([]UnknownKey, len(b.unknownKeys)),
+		}
+	)
+	for i, key := range b.unknownKeys {
+		err.Keys[i] = UnknownKey{
+			Key:         key,
+			Suggestions: suggestKeys(key, names, s.keyMatching),
+		}
+	}
+
+	switch s.unknownKeys {
+	case WarnUnknownKey:
+		if s.onUnknownKeys != nil {
+			s.onUnknownKeys(err)
+		}
+	case RejectUnknownKey:
+		return b.fail(nil, err)
+	}
+	return nil
+}
+
+// fail records err if Struct.collectBindingErrors is set and returns nil
+// to continue the binding, otherwise returns err.
+func (b *structBinding) fail(field *FieldInfoImpl, err error) error {
+	if !b.prototype.collectBindingErrors {
+		return err
+	}
+	b.errors = append(b.errors, fieldError{field, err})
+	return nil
+}
+
+func (b *structBinding) hasFailed(field *FieldInfoImpl) bool {
+	for _, e := range b.errors {
+		if e.field == field {
+			return true
+		}
+	}
+	return false
+}
+
+// collectedErrors returns the collected errors as BindingErrors in the
+// field order of the prototype.
+func (b *structBinding) collectedErrors() error {
+	if len(b.errors) == 0 {
+		return nil
+	}
+
+	// the errors have no field go first
+	ordinals := make(map[*FieldInfoImpl]int, len(b.prototype.orderedFields)+1)
+	ordinals[nil] = -1
+	for i, field := range b.prototype.orderedFields {
+		ordinals[field] = i
+	}
+	sort.SliceStable(b.errors, func(i, j int) bool {
+		return ordinals[b.errors[i].field] < ordinals[b.errors[j].field]
+	})
+
+	errs := make(BindingErrors, len(b.errors))
+	for i, e := range b.errors {
+		errs[i] = e.err
+	}
+	return errs
+}
+
+// acceptAlias reports whether the value received by the alias should be
+// bound with the AliasConflictPolicy.
+func (b *structBinding) acceptAlias(key fieldKey) (bool, error) {
+	s := b.prototype
+
+	if prev, ok := b.boundAliases[key.field]; ok && prev != key.alias {
+		switch s.aliasConflicts {
+		case PrimaryAliasWins:
+			if key.alias > prev {
+				return false, nil
+			}
+		case RejectAliasConflict:
+			return false, fmt.Errorf("conflict with the value bound by '%s'", fieldKey{key.field, prev}.name())
+		}
+	}
+	return true, nil
+}
+
+// notifyDeprecatedAlias calls OnDeprecatedAlias if the value of key, one of
+// the aliases, has been bound.
+func (b *structBinding) notifyDeprecatedAlias(key fieldKey) {
+	s := b.prototype
+
+	if key.alias > 0 && s.onDeprecatedAlias != nil {
+		s.onDeprecatedAlias(key.field, key.name())
+	}
+}
+
+func (b *structBinding) markAliasBound(key fieldKey) {
+	if b.boundAliases == nil {
+		b.boundAliases = make(map[*FieldInfoImpl]int)
+	}
+	b.boundAliases[key.field] = key.alias
+}
+
+func (b *structBinding) markKeyBound(key fieldKey) {
+	if b.boundKeys == nil {
+		b.boundKeys = make(map[fieldKey]bool)
+	}
+	b.boundKeys[key] = true
+}
+
+func (b *structBinding) markBound(field string) {
+	b.removeRequired(field)
+	if !b.defaultFields.isEmpty() {
+		b.defaultFields.remove(field)
+	}
+}
+
+func (b *structBinding) removeRequired(field string) {
+	index := b.requiredFields.indexOf(field)
+	if index != -1 {
+		// eliminate the field from slice if found
+		b.requiredFields.removeIndex(index)
+	}
+}
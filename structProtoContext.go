@@ -9,27 +9,39 @@ func (ctx *StructProtoContext) Target() reflect.Value {
 }
 
 func (ctx *StructProtoContext) FieldInfo(name string) FieldInfo {
-	return ctx.getFieldInfoImpl(name)
+	if field := ctx.getFieldInfoImpl(name); field != nil {
+		return field
+	}
+	return nil
 }
 
+// Field returns the field value specified by name, the nil pointers of
+// nested structs on its path will be allocated.
 func (ctx *StructProtoContext) Field(name string) (v reflect.Value, ok bool) {
-	info := ctx.FieldInfo(name)
+	info := ctx.getFieldInfoImpl(name)
 	if info != nil {
-		return ctx.target.Field(info.Index()), true
+		return fieldByIndexPath(ctx.target, info), true
 	}
 	return reflect.Value{}, false
 }
 
 func (ctx *StructProtoContext) FieldNames() []string {
-	var fields []string = make([]string, len(ctx.fields))
-	for _, v := range ctx.fields {
-		fields[v.index] = v.name
+	var fields []string = make([]string, len(ctx.orderedFields))
+	for i, v := range ctx.orderedFields {
+		fields[i] = v.name
 	}
 	return fields
 }
 
+// RequiredFields returns a copy of the required field names, the
+// prototypes of the same struct type share them.
 func (ctx *StructProtoContext) RequiredFields() []string {
-	return ctx.requiredFields
+	if ctx.requiredFields.isEmpty() {
+		return nil
+	}
+	fields := make([]string, len(ctx.requiredFields))
+	copy(fields, ctx.requiredFields)
+	return fields
 }
 
 func (ctx *StructProtoContext) IsRequired(name string) bool {
@@ -47,7 +59,12 @@ func (ctx *StructProtoContext) CheckIfMissingRequiredFields(visitFieldProc func(
 
 	var requiredFields = ctx.requiredFields.clone()
 
-	for field := range visitFieldProc() {
+	for name := range visitFieldProc() {
+		var field = name
+		if info := ctx.lookupField(name); info != nil {
+			field = info.name
+		}
+
 		index := requiredFields.indexOf(field)
 		if index != -1 {
 			requiredFields.removeIndex(index)
@@ -60,17 +77,14 @@ func (ctx *StructProtoContext) CheckIfMissingRequiredFields(visitFieldProc func(
 	}
 
 	if !requiredFields.isEmpty() {
-		field, _ := requiredFields.get(0)
+		field := ctx.firstMissingField(requiredFields)
 		return &MissingRequiredFieldError{field, nil}
 	}
 	return nil
 }
 
 func (ctx *StructProtoContext) getFieldInfoImpl(name string) *FieldInfoImpl {
-	if field, ok := ctx.fields[name]; ok {
-		return field
-	}
-	return nil
+	return ctx.lookupField(name)
 }
 
 func buildStructProtoContext(s *Struct) *StructProtoContext {
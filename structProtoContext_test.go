@@ -33,6 +33,11 @@ func TestStructProtoContext(t *testing.T) {
 	if !reflect.DeepEqual(expectedRequiredFields, context.RequiredFields()) {
 		t.Errorf("assert 'structprotoContext.AllRequiredFields()':: expected '%#v', got '%#v'", expectedRequiredFields, context.RequiredFields())
 	}
+	// the shared schema is not affected by the changes of the returned fields
+	context.RequiredFields()[0] = "ALIAS"
+	if !reflect.DeepEqual(expectedRequiredFields, context.RequiredFields()) {
+		t.Errorf("assert 'structprotoContext.AllRequiredFields()':: expected '%#v', got '%#v'", expectedRequiredFields, context.RequiredFields())
+	}
 
 	{
 		field := context.getFieldInfoImpl("NAME")
@@ -66,3 +71,45 @@ func TestStructProtoContext(t *testing.T) {
 
 	// TODO: test context.ChechIfMissingRequireFields
 }
+
+func TestStructProtoContext_WithKeyMatching(t *testing.T) {
+	c := struct {
+		Name string `demo:"*NAME"`
+		Age  *int   `demo:"*AGE"`
+	}{}
+
+	prototype, err := Prototypify(&c, &StructProtoResolveOption{
+		TagName:     "demo",
+		KeyMatching: CaseInsensitiveKeyMatching,
+	})
+	if err != nil {
+		t.Error(err)
+	}
+
+	context := buildStructProtoContext(prototype)
+
+	field := context.FieldInfo("name")
+	if field == nil {
+		t.Fatalf("assert 'structprotoContext.FieldInfo(\"name\")':: expected not nil, got '%#v'", field)
+	}
+	if field.Name() != "NAME" {
+		t.Errorf("assert 'FieldInfo.Name()':: expected '%#v', got '%#v'", "NAME", field.Name())
+	}
+	if !context.IsRequired("age") {
+		t.Errorf("assert 'structprotoContext.IsRequired(\"age\")':: expected '%#v', got '%#v'", true, false)
+	}
+	if context.FieldInfo("unknown") != nil {
+		t.Errorf("assert 'structprotoContext.FieldInfo(\"unknown\")':: expected nil")
+	}
+
+	err = context.CheckIfMissingRequiredFields(func() <-chan string {
+		c := make(chan string, 2)
+		c <- "name"
+		c <- "Age"
+		close(c)
+		return c
+	})
+	if err != nil {
+		t.Error(err)
+	}
+}
@@ -3,15 +3,56 @@ package structproto
 import (
 	"fmt"
 	"reflect"
+	"regexp"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 
 	"github.com/Bofry/structproto/tagresolver"
+	"github.com/Bofry/structproto/valuebinder"
+)
+
+var (
+	closureNamePattern = regexp.MustCompile(`\.func\d+(\.\d+)*$|-fm$`)
+
+	// closureFuncs memoizes isClosure by the code pointers
+	closureFuncs sync.Map
 )
 
 type StructProtoResolver struct {
-	tagName     string
-	tagResolver TagResolver
+	tagName        string
+	tagResolver    TagResolver
+	tagNames       []string
+	tagResolvers   map[string]TagResolver
+	namingStrategy NamingStrategy
+	strictTags     bool
+
+	checkDuplicateNames  bool
+	resolveNestedStructs bool
+	unexportedFields     UnexportedFieldPolicy
+	fieldOrder           FieldOrder
+	keyMatching          KeyMatching
+	aliasConflicts       AliasConflictPolicy
+	repeatedKeys         RepeatedKeyPolicy
+	listSeparator        string
+	trimListElements     bool
+	quoteListElements    bool
 
-	checkDuplicateNames bool
+	converters               *valuebinder.ConverterRegistry
+	defaultValueBindProvider ValueBindProvider
+	onDeprecatedAlias        DeprecatedAliasHandler
+	collectBindingErrors     bool
+	atomicBinding            bool
+	unknownKeys              UnknownKeyPolicy
+	onUnknownKeys            UnknownKeyHandler
+
+	// cacheable reports the functions of the resolver can be identified by
+	// their code pointers, the closures cannot.
+	cacheable bool
+	// cacheKey is the schema cache key without the type and the versions
+	cacheKey structSchemaCacheKey
 }
 
 func NewStructProtoResolver(option *StructProtoResolveOption) *StructProtoResolver {
@@ -20,22 +61,57 @@ func NewStructProtoResolver(option *StructProtoResolveOption) *StructProtoResolv
 	}
 
 	r := &StructProtoResolver{
-		tagName:     option.TagName,
-		tagResolver: option.TagResolver,
+		tagName:        option.TagName,
+		tagResolver:    option.TagResolver,
+		tagResolvers:   option.TagResolvers,
+		namingStrategy: option.NamingStrategy,
+		strictTags:     option.StrictTags,
+
+		checkDuplicateNames:  option.CheckDuplicateNames,
+		resolveNestedStructs: option.ResolveNestedStructs,
+		unexportedFields:     option.UnexportedFields,
+		fieldOrder:           option.FieldOrder,
+		keyMatching:          option.KeyMatching,
+		aliasConflicts:       option.AliasConflicts,
+		repeatedKeys:         option.RepeatedKeys,
+		listSeparator:        option.ListSeparator,
+		trimListElements:     option.TrimListElements,
+		quoteListElements:    option.QuoteListElements,
 
-		checkDuplicateNames: option.CheckDuplicateNames,
+		converters:               option.Converters,
+		defaultValueBindProvider: option.DefaultValueBindProvider,
+		onDeprecatedAlias:        option.OnDeprecatedAlias,
+		collectBindingErrors:     option.CollectBindingErrors,
+		atomicBinding:            option.AtomicBinding,
+		unknownKeys:              option.UnknownKeys,
+		onUnknownKeys:            option.OnUnknownKeys,
 	}
 
 	// use StdTagResolver if missing
 	// - or -
 	// use NoneTagResolver if unassign both TagResolver and TagName
+	if len(r.tagName) > 0 {
+		r.tagNames = append(r.tagNames, r.tagName)
+	}
+	for _, name := range option.TagNames {
+		if len(name) > 0 && name != r.tagName {
+			r.tagNames = append(r.tagNames, name)
+		}
+	}
 	if r.tagResolver == nil {
-		if len(r.tagName) > 0 {
+		if len(r.tagNames) > 0 {
 			r.tagResolver = tagresolver.StdTagResolver
 		} else {
 			r.tagResolver = tagresolver.NoneTagResolver
 		}
 	}
+	if r.converters == nil {
+		r.converters = valuebinder.DefaultConverters
+	}
+	r.cacheable = r.isCacheable()
+	if r.cacheable {
+		r.cacheKey = r.baseCacheKey()
+	}
 	return r
 }
 
@@ -76,44 +152,606 @@ func (r *StructProtoResolver) Resolve(target interface{}) (*Struct, error) {
 }
 
 func (r *StructProtoResolver) internalResolve(rv reflect.Value) (*Struct, error) {
-	var prototype = makeStruct(rv)
-	t := rv.Type()
+	schema, err := r.resolveSchema(rv.Type())
+	if err != nil {
+		return nil, err
+	}
+	return r.newStruct(rv, schema), nil
+}
+
+// newStruct binds schema to the struct value rv with the binding options
+// of r.
+func (r *StructProtoResolver) newStruct(rv reflect.Value, schema *structSchema) *Struct {
+	prototype := makeStruct(rv, schema)
+	prototype.onDeprecatedAlias = r.onDeprecatedAlias
+	prototype.collectBindingErrors = r.collectBindingErrors
+	prototype.atomicBinding = r.atomicBinding
+	prototype.unknownKeys = r.unknownKeys
+	prototype.onUnknownKeys = r.onUnknownKeys
+	return prototype
+}
+
+func (r *StructProtoResolver) resolveSchema(t reflect.Type) (*structSchema, error) {
+	if !r.cacheable {
+		return r.buildSchema(t)
+	}
+
+	key := r.schemaCacheKey(t)
+	if schema, ok := defaultStructSchemaCache.load(key); ok {
+		return schema, nil
+	}
+
+	schema, err := r.buildSchema(t)
+	if err != nil {
+		return nil, err
+	}
+	return defaultStructSchemaCache.store(key, schema), nil
+}
+
+func (r *StructProtoResolver) buildSchema(t reflect.Type) (*structSchema, error) {
+	var prototype = makeStructSchema(t, r.keyMatching)
+	prototype.aliasConflicts = r.aliasConflicts
+	candidates, err := r.collectFields(t, &fieldScope{
+		visiting: []reflect.Type{t},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fields, err := r.dominantFields(candidates)
+	if err != nil {
+		return nil, err
+	}
+	if r.fieldOrder == NameFieldOrder {
+		sort.SliceStable(fields, func(i, j int) bool {
+			return fields[i].name < fields[j].name
+		})
+	}
+
+	for _, field := range fields {
+		r.resolveBindAttrs(field)
+
+		if field.HasFlag(RemainFlag) {
+			err := r.validateRemainField(t, field, prototype.remainField)
+			if err != nil {
+				return nil, err
+			}
+			prototype.addRemainField(field)
+			continue
+		}
+
+		err := r.resolveRepeatedKeys(t, field)
+		if err != nil {
+			return nil, err
+		}
+
+		prototype.addField(field)
+		if field.HasFlag(RequiredFlag) {
+			prototype.requiredFields.append(field.name)
+		}
+		if field.defaultValue != nil {
+			err := r.resolveDefaultValue(t, field)
+			if err != nil {
+				return nil, err
+			}
+			prototype.defaultFields.append(field.name)
+		}
+	}
+	prototype.orderedFields = fields
+
+	// register aliases after all names, so the names take precedence
+	for _, field := range fields {
+		if field == prototype.remainField {
+			continue
+		}
+		for i := range field.aliases {
+			if !prototype.addAlias(field, i+1) && r.checkDuplicateNames {
+				return nil, fmt.Errorf("find duplicate name '%s' on field '%s'", field.aliases[i], field.idName)
+			}
+		}
+	}
+	return prototype, nil
+}
+
+func (r *StructProtoResolver) collectFields(t reflect.Type, scope *fieldScope) ([]*fieldCandidate, error) {
+	var candidates []*fieldCandidate
+
 	count := t.NumField()
 	for i := 0; i < count; i++ {
-		fieldname := t.Field(i).Name
-		token := r.getTagContent(t.Field(i))
-		tag, err := r.tagResolver(fieldname, token)
+		sf := t.Field(i)
+		fieldname := sf.Name
+		tagName, token, tagResolver := r.getTagContent(sf)
+
+		if sf.Anonymous && len(token) == 0 {
+			embedded, ok, err := r.collectEmbeddedFields(t, sf, scope, i)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				candidates = append(candidates, embedded...)
+				continue
+			}
+		}
+
+		tag, err := tagResolver(r.nameOf(fieldname), token)
 		if err != nil {
+			if r.strictTags {
+				return nil, &TagValidationError{
+					Type:  t,
+					Field: fieldname,
+					Token: token,
+					Err:   err,
+				}
+			}
 			return nil, err
 		}
 		if tag != nil {
+			if r.strictTags {
+				err := r.validateTag(t, fieldname, tag)
+				if err != nil {
+					return nil, err
+				}
+			}
+			unsafe := scope.unsafe
+			if !sf.IsExported() {
+				accepted, err := r.acceptUnexportedField(t, sf)
+				if err != nil {
+					return nil, err
+				}
+				if !accepted {
+					continue
+				}
+				unsafe = true
+			}
+
 			field := &FieldInfoImpl{
-				idName: fieldname,
-				name:   tag.Name,
-				index:  i,
-				desc:   tag.Desc,
-				tag:    t.Field(i).Tag,
+				idName:    scope.idName + fieldname,
+				name:      scope.name + tag.Name,
+				index:     i,
+				indexPath: scope.indexPathOf(i),
+				desc:      tag.Desc,
+				tag:       sf.Tag,
+				unsafe:    unsafe,
+
+				defaultValue: tag.Default,
+				aliases:      scope.namesOf(tag.Aliases),
+				attrs:        tag.Attrs,
+				tagName:      tagName,
 			}
 			field.appendFlags(tag.Flags...)
 
-			if r.checkDuplicateNames {
-				_, ok := prototype.fields[tag.Name]
-				if ok {
-					return nil, fmt.Errorf("find duplicate name '%s' on field '%s'", tag.Name, fieldname)
-				}
+			nested, ok, err := r.collectNestedFields(sf.Type, field, scope)
+			if err != nil {
+				return nil, err
 			}
-			prototype.fields[tag.Name] = field
-			if field.HasFlag(RequiredFlag) {
-				prototype.requiredFields.append(tag.Name)
+			if ok {
+				candidates = append(candidates, nested...)
+				continue
 			}
+
+			candidates = append(candidates, &fieldCandidate{
+				FieldInfoImpl: field,
+				depth:         scope.depth,
+				tagged:        len(token) > 0,
+			})
 		}
 	}
-	return prototype, nil
+	return candidates, nil
 }
 
-func (r *StructProtoResolver) getTagContent(field reflect.StructField) string {
-	if len(r.tagName) > 0 {
-		return field.Tag.Get(r.tagName)
+// collectEmbeddedFields collects the fields of an untagged anonymous
+// struct field which will be promoted to its owner.
+func (r *StructProtoResolver) collectEmbeddedFields(t reflect.Type, sf reflect.StructField, scope *fieldScope, index int) ([]*fieldCandidate, bool, error) {
+	var unsafe bool
+
+	elem := sf.Type
+	if elem.Kind() == reflect.Ptr {
+		elem = elem.Elem()
+		if elem.Kind() == reflect.Struct && !sf.IsExported() {
+			// the embedded pointer to unexported struct cannot be allocated
+			accepted, err := r.acceptUnexportedField(t, sf)
+			if err != nil {
+				return nil, false, err
+			}
+			if !accepted {
+				return nil, true, nil
+			}
+			unsafe = true
+		}
+	}
+	if elem.Kind() != reflect.Struct || r.isValueStructType(sf.Type) {
+		return nil, false, nil
+	}
+	if scope.isVisiting(elem) {
+		// Go does not promote fields through a recursive embedding, ignore it
+		return nil, true, nil
+	}
+
+	candidates, err := r.collectFields(elem, scope.embed(index, unsafe).visit(elem))
+	if err != nil {
+		return nil, false, err
+	}
+	return candidates, true, nil
+}
+
+func (r *StructProtoResolver) collectNestedFields(t reflect.Type, field *FieldInfoImpl, scope *fieldScope) ([]*fieldCandidate, bool, error) {
+	explicit := field.HasFlag(NestedFlag)
+	if !explicit && !r.resolveNestedStructs {
+		return nil, false, nil
+	}
+
+	elem := t
+	if elem.Kind() == reflect.Ptr {
+		elem = elem.Elem()
+	}
+	if elem.Kind() != reflect.Struct {
+		if explicit {
+			return nil, false, fmt.Errorf("cannot resolve field '%s' with flag '%s' on non-struct type %s", field.idName, NestedFlag, t)
+		}
+		return nil, false, nil
+	}
+	if scope.isVisiting(elem) {
+		if explicit {
+			return nil, false, fmt.Errorf("cannot resolve field '%s' with flag '%s' on recursive type %s", field.idName, NestedFlag, t)
+		}
+		return nil, false, nil
+	}
+	if !explicit && r.isValueStructType(t) {
+		return nil, false, nil
+	}
+
+	candidates, err := r.collectFields(elem, scope.nest(field, elem))
+	if err != nil {
+		return nil, false, err
+	}
+	if !explicit && len(candidates) == 0 {
+		// treat as a single field if nothing can be resolved
+		return nil, false, nil
+	}
+	return candidates, true, nil
+}
+
+// resolveDefaultValue converts the default value of field once, the
+// bindings assign the copies of it regardless of their ValueBindProvider.
+func (r *StructProtoResolver) resolveDefaultValue(t reflect.Type, field *FieldInfoImpl) error {
+	rv := reflect.New(t.FieldByIndex(field.indexPath).Type).Elem()
+	buildValueBinder := r.defaultValueBindProvider
+	if buildValueBinder == nil {
+		buildValueBinder = r.converters.BuildStringBinder
+	}
+	binder := field.withAttrs(buildValueBinder(rv))
+	if binder == nil {
+		return nil
+	}
+	err := binder.Bind(*field.defaultValue)
+	if err != nil {
+		return fmt.Errorf("cannot resolve default value '%s' on field '%s'. %w", *field.defaultValue, field.idName, err)
+	}
+	field.boundDefaultValue = rv
+	return nil
+}
+
+// validateTag rejects the unknown flags and attributes, the malformed
+// names and the conflicting tokens of tag.
+func (r *StructProtoResolver) validateTag(t reflect.Type, fieldname string, tag *Tag) error {
+	fail := func(token string, format string, args ...interface{}) error {
+		return &TagValidationError{
+			Type:  t,
+			Field: fieldname,
+			Token: token,
+			Err:   fmt.Errorf(format, args...),
+		}
+	}
+
+	names := append([]string{tag.Name}, tag.Aliases...)
+	for _, name := range names {
+		if strings.HasPrefix(name, "*") {
+			return fail(name, "required prefix '*' cannot be combined with flags, use flag '%s' instead", RequiredFlag)
+		}
+		if strings.ContainsAny(name, malformedTagNameChars) {
+			return fail(name, "malformed name")
+		}
+	}
+	for _, flag := range tag.Flags {
+		if len(flag) == 0 {
+			continue
+		}
+		if !defaultTagRegistry.hasFlag(flag) {
+			return fail(flag, "unknown flag")
+		}
+	}
+	for key := range tag.Attrs {
+		if !defaultTagRegistry.hasAttr(key) {
+			return fail(key, "unknown attribute")
+		}
+	}
+	if x, y, ok := defaultTagRegistry.findConflict(tag); ok {
+		return fail(y, "conflicts with '%s'", x)
+	}
+	return nil
+}
+
+// resolveBindAttrs merges the resolver defaults of the list attributes into
+// the attributes of field passed to the AttrBinder.
+func (r *StructProtoResolver) resolveBindAttrs(field *FieldInfoImpl) {
+	field.bindAttrs = field.attrs
+	if len(r.listSeparator) == 0 && !r.trimListElements && !r.quoteListElements {
+		return
+	}
+
+	attrs := make(map[string]string, len(field.attrs)+3)
+	if len(r.listSeparator) > 0 {
+		attrs[SepAttr] = r.listSeparator
+	}
+	if r.trimListElements {
+		attrs[TrimAttr] = "true"
+	}
+	if r.quoteListElements {
+		attrs[QuoteAttr] = "true"
+	}
+	for k, v := range field.attrs {
+		attrs[k] = v
+	}
+	field.bindAttrs = attrs
+}
+
+func (r *StructProtoResolver) resolveRepeatedKeys(t reflect.Type, field *FieldInfoImpl) error {
+	var isSlice = t.FieldByIndex(field.indexPath).Type.Kind() == reflect.Slice
+
+	v, ok := field.Attr(RepeatAttr)
+	if !ok {
+		field.repeatedKeys = r.repeatedKeys
+		if field.repeatedKeys == AccumulateRepeatedKey && !isSlice {
+			field.repeatedKeys = LastKeyWins
+		}
+		return nil
+	}
+
+	switch v {
+	case "last":
+		field.repeatedKeys = LastKeyWins
+	case "first":
+		field.repeatedKeys = FirstKeyWins
+	case "reject":
+		field.repeatedKeys = RejectRepeatedKey
+	case "accumulate":
+		if !isSlice {
+			return fmt.Errorf("cannot resolve field '%s' with attribute '%s=%s' on non-slice type %s", field.idName, RepeatAttr, v, t.FieldByIndex(field.indexPath).Type)
+		}
+		field.repeatedKeys = AccumulateRepeatedKey
+	default:
+		return fmt.Errorf("cannot resolve field '%s' with unknown attribute value '%s=%s'", field.idName, RepeatAttr, v)
+	}
+	return nil
+}
+
+func (r *StructProtoResolver) validateRemainField(t reflect.Type, field, prev *FieldInfoImpl) error {
+	if prev != nil {
+		return fmt.Errorf("cannot resolve field '%s' with flag '%s', field '%s' has been declared", field.idName, RemainFlag, prev.idName)
+	}
+	ft := t.FieldByIndex(field.indexPath).Type
+	if ft.Kind() != reflect.Map || ft.Key().Kind() != reflect.String {
+		return fmt.Errorf("cannot resolve field '%s' with flag '%s' on type %s, it must be a map with string keys", field.idName, RemainFlag, ft)
+	}
+	return nil
+}
+
+func (r *StructProtoResolver) acceptUnexportedField(t reflect.Type, sf reflect.StructField) (bool, error) {
+	switch r.unexportedFields {
+	case RejectUnexportedField:
+		return false, fmt.Errorf("cannot resolve unexported field '%s' of type %s", sf.Name, t)
+	case UnsafeBindUnexportedField:
+		return true, nil
+	}
+	return false, nil
+}
+
+// dominantFields applies Go's field promotion rules on the fields which
+// have the same name. The shallowest field wins, and the tagged one wins
+// if there are several at the same depth, otherwise the name is ambiguous
+// and all of them are dropped.
+func (r *StructProtoResolver) dominantFields(candidates []*fieldCandidate) ([]*FieldInfoImpl, error) {
+	var (
+		fields = make([]*FieldInfoImpl, 0, len(candidates))
+		groups = make(map[string][]*fieldCandidate, len(candidates))
+	)
+	for _, c := range candidates {
+		key := r.keyMatching.normalize(c.name)
+		groups[key] = append(groups[key], c)
+	}
+
+	for _, c := range candidates {
+		group := groups[r.keyMatching.normalize(c.name)]
+		if len(group) == 1 {
+			fields = append(fields, c.FieldInfoImpl)
+			continue
+		}
+
+		dominant, err := r.dominantField(group)
+		if err != nil {
+			return nil, err
+		}
+		if dominant == c {
+			fields = append(fields, c.FieldInfoImpl)
+		}
+	}
+	return fields, nil
+}
+
+func (r *StructProtoResolver) dominantField(group []*fieldCandidate) (*fieldCandidate, error) {
+	var (
+		depth     = group[0].depth
+		shallowed []*fieldCandidate
+	)
+	for _, c := range group {
+		if c.depth < depth {
+			depth = c.depth
+		}
+	}
+	for _, c := range group {
+		if c.depth == depth {
+			shallowed = append(shallowed, c)
+		}
+	}
+	if len(shallowed) == 1 {
+		return shallowed[0], nil
+	}
+
+	if r.checkDuplicateNames {
+		c := shallowed[len(shallowed)-1]
+		return nil, fmt.Errorf("find duplicate name '%s' on field '%s'", c.name, c.idName)
+	}
+	if depth == 0 {
+		// the last one wins on the fields declared in the same struct
+		return shallowed[len(shallowed)-1], nil
+	}
+
+	var tagged *fieldCandidate
+	for _, c := range shallowed {
+		if c.tagged {
+			if tagged != nil {
+				return nil, nil
+			}
+			tagged = c
+		}
+	}
+	return tagged, nil
+}
+
+func (r *StructProtoResolver) schemaCacheKey(t reflect.Type) structSchemaCacheKey {
+	key := r.cacheKey
+	key.typ = t
+	key.convertersVersion = r.converters.Version()
+	if r.strictTags {
+		key.tagRegistryVersion = tagRegistryVersion.Load()
+	}
+	return key
+}
+
+func (r *StructProtoResolver) baseCacheKey() structSchemaCacheKey {
+	return structSchemaCacheKey{
+		tags:                 r.tagsCacheKey(),
+		tagResolver:          reflect.ValueOf(r.tagResolver).Pointer(),
+		namingStrategy:       funcPointer(r.namingStrategy),
+		strictTags:           r.strictTags,
+		checkDuplicateNames:  r.checkDuplicateNames,
+		resolveNestedStructs: r.resolveNestedStructs,
+		unexportedFields:     r.unexportedFields,
+		fieldOrder:           r.fieldOrder,
+		keyMatching:          r.keyMatching,
+		aliasConflicts:       r.aliasConflicts,
+		repeatedKeys:         r.repeatedKeys,
+		listSeparator:        r.listSeparator,
+		trimListElements:     r.trimListElements,
+		quoteListElements:    r.quoteListElements,
+
+		converters:               r.converters,
+		defaultValueBindProvider: funcPointer(r.defaultValueBindProvider),
+	}
+}
+
+func (r *StructProtoResolver) nameOf(fieldname string) string {
+	if r.namingStrategy != nil {
+		return r.namingStrategy(fieldname)
+	}
+	return fieldname
+}
+
+// getTagContent returns the first non-empty tag of field in the tag
+// names, with the TagResolver for it.
+func (r *StructProtoResolver) getTagContent(field reflect.StructField) (string, string, TagResolver) {
+	for _, name := range r.tagNames {
+		if token, ok := field.Tag.Lookup(name); ok && len(token) > 0 {
+			return name, token, r.getTagResolver(name)
+		}
+	}
+	return "", "", r.tagResolver
+}
+
+func (r *StructProtoResolver) getTagResolver(tagName string) TagResolver {
+	if resolver, ok := r.tagResolvers[tagName]; ok && resolver != nil {
+		return resolver
+	}
+	if tagName == JsonTagName && tagName != r.tagName {
+		return tagresolver.JsonTagResolver
+	}
+	return r.tagResolver
+}
+
+func (r *StructProtoResolver) tagsCacheKey() string {
+	var sb strings.Builder
+	for _, name := range r.tagNames {
+		sb.WriteString(name)
+		sb.WriteByte('=')
+		sb.WriteString(strconv.FormatUint(uint64(reflect.ValueOf(r.getTagResolver(name)).Pointer()), 16))
+		sb.WriteByte(';')
+	}
+	return sb.String()
+}
+
+// isValueStructType reports the struct or pointer to struct type t is bound
+// as a single value, by an unmarshaler or a converter of r.converters.
+func (r *StructProtoResolver) isValueStructType(t reflect.Type) bool {
+	if r.converters.IsKnownType(t) {
+		return true
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+		if r.converters.IsKnownType(t) {
+			return true
+		}
+	}
+	ptr := reflect.PointerTo(t)
+	return ptr.Implements(typeOfUnmarshaler) ||
+		ptr.Implements(typeOfTextUnmarshaler) ||
+		ptr.Implements(typeOfBinaryUnmarshaler) ||
+		ptr.Implements(typeOfJsonUnmarshaler)
+}
+
+// isCacheable reports none of the functions of r is a closure, which shares
+// the code pointer with the other closures built from the same literal but
+// captures different variables.
+func (r *StructProtoResolver) isCacheable() bool {
+	if isClosure(r.tagResolver) ||
+		isClosure(r.namingStrategy) ||
+		isClosure(r.defaultValueBindProvider) {
+		return false
+	}
+	for _, resolver := range r.tagResolvers {
+		if isClosure(resolver) {
+			return false
+		}
+	}
+	return true
+}
+
+// isClosure reports fn is a function literal, a method value or a function
+// made by reflect, they are named like "pkg.F.func1", "pkg.T.M-fm" and
+// "reflect.makeFuncStub" by the runtime.
+func isClosure(fn interface{}) bool {
+	rv := reflect.ValueOf(fn)
+	if !rv.IsValid() || rv.IsNil() {
+		return false
+	}
+	pc := rv.Pointer()
+	if v, ok := closureFuncs.Load(pc); ok {
+		return v.(bool)
+	}
+
+	closure := true
+	if f := runtime.FuncForPC(pc); f != nil {
+		name := f.Name()
+		closure = strings.HasPrefix(name, "reflect.") ||
+			closureNamePattern.MatchString(name)
+	}
+	closureFuncs.Store(pc, closure)
+	return closure
+}
+
+func funcPointer(fn interface{}) uintptr {
+	rv := reflect.ValueOf(fn)
+	if rv.IsNil() {
+		return 0
 	}
-	return ""
+	return rv.Pointer()
 }
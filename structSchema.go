@@ -0,0 +1,112 @@
+package structproto
+
+import "reflect"
+
+type structSchema struct {
+	typ            reflect.Type
+	keyMatching    KeyMatching
+	aliasConflicts AliasConflictPolicy
+
+	fields         map[string]*FieldInfoImpl
+	keys           map[string]fieldKey
+	orderedFields  []*FieldInfoImpl
+	requiredFields FieldFlagSet
+	defaultFields  FieldFlagSet
+	// remainField receives the keys which match no other field
+	remainField *FieldInfoImpl
+}
+
+// fieldKey represents a name of field, alias is 0 if it is the primary
+// name, otherwise it is the position of FieldInfoImpl.aliases plus 1.
+type fieldKey struct {
+	field *FieldInfoImpl
+	alias int
+}
+
+func (k fieldKey) name() string {
+	if k.alias == 0 {
+		return k.field.name
+	}
+	return k.field.aliases[k.alias-1]
+}
+
+func makeStructSchema(t reflect.Type, keyMatching KeyMatching) *structSchema {
+	schema := structSchema{
+		typ:         t,
+		keyMatching: keyMatching,
+		fields:      make(map[string]*FieldInfoImpl, t.NumField()),
+		keys:        make(map[string]fieldKey, t.NumField()),
+	}
+	return &schema
+}
+
+func (schema *structSchema) addField(field *FieldInfoImpl) {
+	schema.fields[field.name] = field
+	schema.keys[schema.keyMatching.normalize(field.name)] = fieldKey{field, 0}
+}
+
+// addRemainField registers the field with RemainFlag, which cannot be
+// looked up by its name.
+func (schema *structSchema) addRemainField(field *FieldInfoImpl) {
+	schema.fields[field.name] = field
+	schema.remainField = field
+}
+
+// addAlias registers the alias of field, it reports false if the alias
+// has been taken.
+func (schema *structSchema) addAlias(field *FieldInfoImpl, alias int) bool {
+	key := schema.keyMatching.normalize(field.aliases[alias-1])
+	if _, ok := schema.keys[key]; ok {
+		return false
+	}
+	schema.keys[key] = fieldKey{field, alias}
+	return true
+}
+
+// lookupField finds the field matches the key or one of its aliases with
+// the KeyMatching of the schema.
+func (schema *structSchema) lookupField(key string) *FieldInfoImpl {
+	if k, ok := schema.lookupFieldKey(key); ok {
+		return k.field
+	}
+	return nil
+}
+
+func (schema *structSchema) lookupFieldKey(key string) (fieldKey, bool) {
+	k, ok := schema.keys[schema.keyMatching.normalize(key)]
+	return k, ok
+}
+
+// names returns the names and aliases of the fields in prototype order.
+func (schema *structSchema) names() []string {
+	var names = make([]string, 0, len(schema.keys))
+	for _, field := range schema.orderedFields {
+		names = append(names, field.name)
+		names = append(names, field.aliases...)
+	}
+	return names
+}
+
+// missingFields returns the fields in the prototype order which still
+// remain in the specified set.
+func (schema *structSchema) missingFields(remaining *FieldFlagSet) []string {
+	var fields []string
+	for _, field := range schema.orderedFields {
+		if remaining.has(field.name) {
+			fields = append(fields, field.name)
+		}
+	}
+	return fields
+}
+
+// firstMissingField returns the first field in the prototype order which
+// still remains in the specified set.
+func (schema *structSchema) firstMissingField(remaining *FieldFlagSet) string {
+	for _, field := range schema.orderedFields {
+		if remaining.has(field.name) {
+			return field.name
+		}
+	}
+	name, _ := remaining.get(0)
+	return name
+}
@@ -0,0 +1,134 @@
+package structproto
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/Bofry/structproto/valuebinder"
+)
+
+var (
+	defaultStructSchemaCache = new(structSchemaCache)
+)
+
+type structSchemaCacheKey struct {
+	typ reflect.Type
+	// the tag names and the pointers of their TagResolver
+	tags string
+	// NOTE: the functions are identified by their code pointers, the
+	// resolvers with closures are never cached.
+	tagResolver    uintptr
+	namingStrategy uintptr
+	strictTags     bool
+	// the tagRegistryVersion if strictTags, zero otherwise
+	tagRegistryVersion   uint64
+	checkDuplicateNames  bool
+	resolveNestedStructs bool
+	unexportedFields     UnexportedFieldPolicy
+	fieldOrder           FieldOrder
+	keyMatching          KeyMatching
+	aliasConflicts       AliasConflictPolicy
+	repeatedKeys         RepeatedKeyPolicy
+	listSeparator        string
+	trimListElements     bool
+	quoteListElements    bool
+
+	converters               *valuebinder.ConverterRegistry
+	defaultValueBindProvider uintptr
+	// the version of converters, which decides the nested structs and
+	// converts the default values
+	convertersVersion uint64
+}
+
+type structSchemaCache struct {
+	entries sync.Map
+}
+
+func (c *structSchemaCache) load(key structSchemaCacheKey) (*structSchema, bool) {
+	if v, ok := c.entries.Load(key); ok {
+		return v.(*structSchema), true
+	}
+	return nil, false
+}
+
+func (c *structSchemaCache) store(key structSchemaCacheKey, schema *structSchema) *structSchema {
+	// keep the first stored schema if another goroutine resolved the
+	// same type concurrently, so all prototypes share one instance
+	v, _ := c.entries.LoadOrStore(key, schema)
+	return v.(*structSchema)
+}
+
+func (c *structSchemaCache) purge(types ...reflect.Type) {
+	if len(types) == 0 {
+		c.entries.Range(func(key, value interface{}) bool {
+			c.entries.Delete(key)
+			return true
+		})
+		return
+	}
+
+	c.entries.Range(func(key, value interface{}) bool {
+		for _, t := range types {
+			if key.(structSchemaCacheKey).typ == t {
+				c.entries.Delete(key)
+				break
+			}
+		}
+		return true
+	})
+}
+
+// WarmCache resolves the specified targets with option and stores their
+// field layouts in the prototype cache, so the first Prototypify call on
+// a hot path does not pay for the reflection.
+func WarmCache(option *StructProtoResolveOption, targets ...interface{}) error {
+	r := NewStructProtoResolver(option)
+	for _, target := range targets {
+		if target == nil {
+			panic("specified argument 'targets' cannot contain nil")
+		}
+		if _, err := r.Resolve(target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PurgeCache removes the cached field layouts of the specified targets'
+// struct types. All cached field layouts are removed if no target is
+// specified.
+func PurgeCache(targets ...interface{}) {
+	var types []reflect.Type
+	for _, target := range targets {
+		if t := indirectStructType(target); t != nil {
+			types = append(types, t)
+		}
+	}
+	if len(targets) > 0 && len(types) == 0 {
+		return
+	}
+	defaultStructSchemaCache.purge(types...)
+}
+
+func indirectStructType(target interface{}) reflect.Type {
+	var t reflect.Type
+	switch target := target.(type) {
+	case reflect.Type:
+		t = target
+	case reflect.Value:
+		if !target.IsValid() {
+			return nil
+		}
+		t = target.Type()
+	default:
+		t = reflect.TypeOf(target)
+	}
+
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+	return t
+}
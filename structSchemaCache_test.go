@@ -0,0 +1,348 @@
+package structproto
+
+import (
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Bofry/structproto/tagresolver"
+	"github.com/Bofry/structproto/valuebinder"
+)
+
+func TestStructSchemaCache(t *testing.T) {
+	type model struct {
+		Name        string    `demo:"*NAME"`
+		Age         *int      `demo:"*AGE"`
+		DateOfBirth time.Time `demo:"DATE_OF_BIRTH"`
+	}
+	defer PurgeCache()
+
+	var (
+		a, b   model
+		option = &StructProtoResolveOption{
+			TagName: "demo",
+		}
+	)
+
+	pa, err := Prototypify(&a, option)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pb, err := Prototypify(&b, option)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pa.structSchema != pb.structSchema {
+		t.Errorf("assert 'Struct.structSchema':: expected the same cached schema, got %p and %p", pa.structSchema, pb.structSchema)
+	}
+	if pa.target.Addr().Pointer() == pb.target.Addr().Pointer() {
+		t.Errorf("assert 'Struct.target':: expected distinct targets")
+	}
+
+	// different option should not share the schema
+	pc, err := Prototypify(&a, &StructProtoResolveOption{
+		TagName:             "demo",
+		CheckDuplicateNames: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pa.structSchema == pc.structSchema {
+		t.Errorf("assert 'Struct.structSchema':: expected a different schema for different option")
+	}
+
+	// the list defaults are resolved into the schema
+	pl, err := Prototypify(&a, &StructProtoResolveOption{
+		TagName:       "demo",
+		ListSeparator: ";",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pa.structSchema == pl.structSchema {
+		t.Errorf("assert 'Struct.structSchema':: expected a different schema for different ListSeparator")
+	}
+
+	PurgeCache(&a)
+	pd, err := Prototypify(&a, option)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pa.structSchema == pd.structSchema {
+		t.Errorf("assert 'Struct.structSchema':: expected a new schema after PurgeCache()")
+	}
+}
+
+func TestWarmCache(t *testing.T) {
+	type model struct {
+		Name string `demo:"*NAME"`
+	}
+	defer PurgeCache()
+
+	option := &StructProtoResolveOption{
+		TagName: "demo",
+	}
+	err := WarmCache(option, (*model)(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := NewStructProtoResolver(option)
+	schema, ok := defaultStructSchemaCache.load(r.schemaCacheKey(reflect.TypeOf(model{})))
+	if !ok {
+		t.Fatalf("assert 'WarmCache()':: expected schema to be cached")
+	}
+
+	var m model
+	prototype, err := Prototypify(&m, option)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prototype.structSchema != schema {
+		t.Errorf("assert 'Struct.structSchema':: expected the warmed schema")
+	}
+}
+
+func TestStructSchemaCache_Concurrent(t *testing.T) {
+	type model struct {
+		Name string `demo:"*NAME"`
+		Age  int    `demo:"AGE"`
+	}
+	defer PurgeCache()
+
+	var (
+		wg      sync.WaitGroup
+		schemas = make([]*structSchema, 16)
+	)
+	for i := range schemas {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			var m model
+			prototype, err := Prototypify(&m, &StructProtoResolveOption{
+				TagName: "demo",
+			})
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			schemas[i] = prototype.structSchema
+		}(i)
+	}
+	wg.Wait()
+
+	for i := 1; i < len(schemas); i++ {
+		if schemas[i] != schemas[0] {
+			t.Errorf("assert 'Struct.structSchema':: expected all prototypes to share one schema")
+			break
+		}
+	}
+}
+
+func TestStructSchemaCache_WithClosures(t *testing.T) {
+	type model struct {
+		Name string
+		Port int
+	}
+	defer PurgeCache()
+
+	var builders = map[string]func(prefix string) NamingStrategy{
+		"literal":  prefixNaming,
+		"MakeFunc": reflectPrefixNaming,
+	}
+	for kind, build := range builders {
+		var a, b model
+		pa, err := Prototypify(&a, &StructProtoResolveOption{
+			NamingStrategy: build("A_"),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		pb, err := Prototypify(&b, &StructProtoResolveOption{
+			NamingStrategy: build("B_"),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pa.structSchema == pb.structSchema {
+			t.Errorf("assert 'Struct.structSchema' of %s:: expected distinct schemas for distinct closures", kind)
+		}
+
+		err = pb.BindMap(map[string]interface{}{
+			"B_Name": "luffy",
+			"B_Port": "80",
+		}, valuebinder.BuildStringBinder)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := model{Name: "luffy", Port: 80}
+		if b != expected {
+			t.Errorf("assert 'model' of %s:: expected '%+v', got '%+v'", kind, expected, b)
+		}
+	}
+}
+
+func TestIsClosure(t *testing.T) {
+	var cases = []struct {
+		fn       interface{}
+		expected bool
+	}{
+		{nil, false},
+		{NamingStrategy(nil), false},
+		{tagresolver.StdTagResolver, false},
+		{valuebinder.BuildStringBinder, false},
+		{func(name string) string { return name }, true},
+		{valuebinder.NewConverterRegistry().BuildStringBinder, true},
+		{reflectPrefixNaming("A_"), true},
+	}
+	for i, c := range cases {
+		if v := isClosure(c.fn); v != c.expected {
+			t.Errorf("assert 'isClosure(#%d)':: expected '%v', got '%v'", i, c.expected, v)
+		}
+	}
+}
+
+func prefixNaming(prefix string) NamingStrategy {
+	return func(name string) string {
+		return prefix + name
+	}
+}
+
+// reflectPrefixNaming builds the NamingStrategy by reflect.MakeFunc, all
+// of them share one code pointer.
+func reflectPrefixNaming(prefix string) NamingStrategy {
+	var fn NamingStrategy
+	rv := reflect.MakeFunc(reflect.TypeOf(fn), func(args []reflect.Value) []reflect.Value {
+		return []reflect.Value{reflect.ValueOf(prefix + args[0].String())}
+	})
+	reflect.ValueOf(&fn).Elem().Set(rv)
+	return fn
+}
+
+func TestStructSchemaCache_WithConverterRegistered(t *testing.T) {
+	type (
+		balance struct {
+			Units int64  `demo:"units"`
+			Cur   string `demo:"cur"`
+		}
+		model struct {
+			Bal balance `demo:"bal"`
+		}
+	)
+	defer PurgeCache()
+
+	option := &StructProtoResolveOption{
+		TagName:              "demo",
+		ResolveNestedStructs: true,
+	}
+	var a model
+	if _, err := Prototypify(&a, option); err != nil {
+		t.Fatal(err)
+	}
+
+	typeOfBalance := reflect.TypeOf(balance{})
+	valuebinder.RegisterConverter(typeOfBalance, func(rv reflect.Value, v interface{}) error {
+		rv.Set(reflect.ValueOf(balance{Units: 100, Cur: v.(string)}))
+		return nil
+	})
+	defer valuebinder.DefaultConverters.Unregister(typeOfBalance)
+
+	var b model
+	pb, err := Prototypify(&b, option)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = pb.BindMap(map[string]interface{}{
+		"bal": "USD",
+	}, valuebinder.BuildStringBinder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := balance{Units: 100, Cur: "USD"}
+	if b.Bal != expected {
+		t.Errorf("assert 'model.Bal':: expected '%+v', got '%+v'", expected, b.Bal)
+	}
+}
+
+func TestStructSchemaCache_WithConverters(t *testing.T) {
+	type (
+		amount struct {
+			Cents int64 `demo:"cents"`
+		}
+		model struct {
+			Price amount `demo:"PRICE,default=150"`
+			Fee   amount `demo:"FEE"`
+		}
+	)
+	defer PurgeCache()
+
+	typeOfAmount := reflect.TypeOf(amount{})
+	converters := valuebinder.NewConverterRegistry()
+	converters.Register(typeOfAmount, func(rv reflect.Value, v interface{}) error {
+		cents, err := strconv.ParseInt(v.(string), 10, 64)
+		if err != nil {
+			return err
+		}
+		rv.Set(reflect.ValueOf(amount{Cents: cents}))
+		return nil
+	})
+	option := &StructProtoResolveOption{
+		TagName:              "demo",
+		ResolveNestedStructs: true,
+		Converters:           converters,
+	}
+
+	var a, b model
+	pa, err := Prototypify(&a, option)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pb, err := Prototypify(&b, option)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pa.structSchema != pb.structSchema {
+		t.Errorf("assert 'Struct.structSchema':: expected the schema shared by the Converters")
+	}
+
+	err = pa.BindMap(map[string]interface{}{
+		"FEE": "20",
+	}, converters.BuildStringBinder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := model{Price: amount{150}, Fee: amount{20}}
+	if a != expected {
+		t.Errorf("assert 'model':: expected '%+v', got '%+v'", expected, a)
+	}
+
+	// the default values are converted at resolve time, the provider of
+	// the binding does not convert them again
+	err = pb.BindMap(map[string]interface{}{
+		"FEE.cents": "20",
+	}, valuebinder.BuildStringBinder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Price != expected.Price {
+		t.Errorf("assert 'model.Price':: expected '%+v', got '%+v'", expected.Price, b.Price)
+	}
+
+	// the other registries resolve their own schemas
+	var c struct {
+		Fee amount `demo:"FEE"`
+	}
+	pc, err := Prototypify(&c, &StructProtoResolveOption{
+		TagName:              "demo",
+		ResolveNestedStructs: true,
+		Converters:           valuebinder.NewConverterRegistry(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pc.lookupField("FEE.cents") == nil {
+		t.Errorf("assert 'Struct.lookupField(\"FEE.cents\")':: expected not nil")
+	}
+}
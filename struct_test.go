@@ -95,3 +95,31 @@ func TestStructInternal(t *testing.T) {
 		t.Errorf("assert 'mockCharacter.requiredFields':: expected '%#v', got '%#v'", expectedRequiredFields, prototype.requiredFields)
 	}
 }
+
+func TestFieldInfoImpl_Attr(t *testing.T) {
+	c := struct {
+		Tags []string `demo:"TAGS,required,sep=';',max=10"`
+	}{}
+
+	prototype, err := Prototypify(&c, &StructProtoResolveOption{
+		TagName: "demo",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	field := prototype.fields["TAGS"]
+	if v, ok := field.Attr("sep"); !ok || v != ";" {
+		t.Errorf("assert 'FieldInfo.Attr(\"sep\")':: expected '%v', got '%v'", ";", v)
+	}
+	if v, ok := field.Attr("max"); !ok || v != "10" {
+		t.Errorf("assert 'FieldInfo.Attr(\"max\")':: expected '%v', got '%v'", "10", v)
+	}
+	if _, ok := field.Attr("unknown"); ok {
+		t.Errorf("assert 'FieldInfo.Attr(\"unknown\")':: expected not found")
+	}
+	expectedFlags := []string{"required"}
+	if !reflect.DeepEqual(expectedFlags, field.Flags()) {
+		t.Errorf("assert 'FieldInfo.Flags()':: expected '%#v', got '%#v'", expectedFlags, field.Flags())
+	}
+}
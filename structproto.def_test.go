@@ -1,7 +1,10 @@
 package structproto_test
 
 import (
+	"context"
 	"reflect"
+	"runtime"
+	"time"
 
 	"github.com/Bofry/structproto"
 	"github.com/Bofry/structproto/valuebinder"
@@ -71,3 +74,72 @@ func (set EntitySet) Iterate() <-chan structproto.FieldValueEntity {
 	}()
 	return c
 }
+
+// -------------------------------------
+
+var _ structproto.ContextIterator = ContextEntitySet(nil)
+
+// ContextEntitySet is an Iterator which stops once the ctx is done.
+type ContextEntitySet [][2]string
+
+func (set ContextEntitySet) Iterate() <-chan structproto.FieldValueEntity {
+	return set.IterateContext(context.Background())
+}
+
+func (set ContextEntitySet) IterateContext(ctx context.Context) <-chan structproto.FieldValueEntity {
+	c := make(chan structproto.FieldValueEntity, 1)
+	go func() {
+		defer close(c)
+		for _, v := range set {
+			select {
+			case c <- structproto.FieldValueEntity{
+				Field: v[0],
+				Value: v[1],
+			}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return c
+}
+
+// -------------------------------------
+
+var _ structproto.SeqIterator = SeqEntitySet(nil)
+
+// SeqEntitySet is an Iterator which is consumed by its Seq.
+type SeqEntitySet [][2]string
+
+func (set SeqEntitySet) Iterate() <-chan structproto.FieldValueEntity {
+	return EntitySet(set).Iterate()
+}
+
+func (set SeqEntitySet) Seq() structproto.FieldValueSeq {
+	return func(yield func(structproto.FieldValueEntity) bool) {
+		for _, v := range set {
+			ok := yield(structproto.FieldValueEntity{
+				Field: v[0],
+				Value: v[1],
+			})
+			if !ok {
+				return
+			}
+		}
+	}
+}
+
+// -------------------------------------
+
+// waitGoroutines waits until the number of goroutines drops to n, and
+// reports the final number.
+func waitGoroutines(n int) int {
+	deadline := time.Now().Add(time.Second)
+	for {
+		current := runtime.NumGoroutine()
+		if current <= n || time.Now().After(deadline) {
+			return current
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,129 @@
+package structproto_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Bofry/structproto"
+	"github.com/Bofry/structproto/valuebinder"
+)
+
+type benchmarkModel struct {
+	Name        string    `demo:"*NAME"`
+	Age         int       `demo:"*AGE"`
+	Alias       []string  `demo:"ALIAS"`
+	DateOfBirth time.Time `demo:"DATE_OF_BIRTH"`
+	Remark      string    `demo:"REMARK"`
+}
+
+var benchmarkValues = map[string]interface{}{
+	"NAME":          "luffy",
+	"AGE":           "19",
+	"ALIAS":         "lucy",
+	"DATE_OF_BIRTH": "2020-05-05T00:00:00Z",
+	"REMARK":        "none",
+}
+
+func BenchmarkStruct_BindMap(b *testing.B) {
+	s := benchmarkModel{}
+	prototype, err := structproto.Prototypify(&s, &structproto.StructProtoResolveOption{
+		TagName: "demo",
+	})
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		err := prototype.BindMap(benchmarkValues, valuebinder.BuildStringBinder)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkStruct_BindChan binds the same values through the channel of
+// FieldValueMap as BindMap did before the Seq path.
+func BenchmarkStruct_BindChan(b *testing.B) {
+	s := benchmarkModel{}
+	prototype, err := structproto.Prototypify(&s, &structproto.StructProtoResolveOption{
+		TagName: "demo",
+	})
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		err := prototype.BindChan(structproto.FieldValueMap(benchmarkValues).Iterate(), valuebinder.BuildStringBinder)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkStruct_BindIterator(b *testing.B) {
+	s := benchmarkModel{}
+	prototype, err := structproto.Prototypify(&s, &structproto.StructProtoResolveOption{
+		TagName: "demo",
+	})
+	if err != nil {
+		b.Fatal(err)
+	}
+	set := EntitySet{
+		{"NAME", "luffy"},
+		{"AGE", "19"},
+		{"ALIAS", "lucy"},
+		{"DATE_OF_BIRTH", "2020-05-05T00:00:00Z"},
+		{"REMARK", "none"},
+	}
+
+	b.Run("Seq", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			err := prototype.BindIterator(SeqEntitySet(set), valuebinder.BuildStringBinder)
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Chan", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			err := prototype.BindIterator(set, valuebinder.BuildStringBinder)
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkPrototypify(b *testing.B) {
+	option := &structproto.StructProtoResolveOption{
+		TagName: "demo",
+	}
+
+	b.Run("Cached", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			s := benchmarkModel{}
+			_, err := structproto.Prototypify(&s, option)
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Uncached", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			structproto.PurgeCache()
+			s := benchmarkModel{}
+			_, err := structproto.Prototypify(&s, option)
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
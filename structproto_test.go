@@ -1,13 +1,20 @@
 package structproto_test
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net"
+	"net/mail"
 	"reflect"
+	"runtime"
+	"sort"
 	"testing"
 	"time"
 
 	"github.com/Bofry/structproto"
+	"github.com/Bofry/structproto/naming"
 	"github.com/Bofry/structproto/valuebinder"
 	"go.openly.dev/pointy"
 )
@@ -471,3 +478,2101 @@ func TestStruct_WithStructUnmarshaler(t *testing.T) {
 		t.Errorf("assert:: expected '%+v', got '%+v'", expected, s)
 	}
 }
+
+func TestStruct_BindMap_WithNestedStruct(t *testing.T) {
+	type (
+		DBConfig struct {
+			Host string `demo:"*host"`
+			Port int    `demo:"port"`
+		}
+		CacheConfig struct {
+			Host string `demo:"host"`
+		}
+		model struct {
+			Name  string       `demo:"*name"`
+			DB    DBConfig     `demo:"db,nested"`
+			Cache *CacheConfig `demo:"cache,nested"`
+			Proxy *CacheConfig `demo:"proxy,nested"`
+		}
+	)
+
+	s := model{}
+
+	prototype, err := structproto.Prototypify(&s, &structproto.StructProtoResolveOption{
+		TagName: "demo",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = prototype.BindMap(map[string]interface{}{
+		"name":       "luffy",
+		"db.host":    "localhost",
+		"db.port":    "5432",
+		"cache.host": "redis",
+	}, valuebinder.BuildStringBinder)
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected := model{
+		Name: "luffy",
+		DB: DBConfig{
+			Host: "localhost",
+			Port: 5432,
+		},
+		Cache: &CacheConfig{
+			Host: "redis",
+		},
+	}
+	if !reflect.DeepEqual(expected, s) {
+		t.Errorf("assert:: expected '%+v', got '%+v'", expected, s)
+	}
+
+	// nested required field
+	s = model{}
+	err = prototype.BindMap(map[string]interface{}{
+		"name": "luffy",
+	}, valuebinder.BuildStringBinder)
+	if err == nil {
+		t.Errorf("the 'BindMap()' should throw '%s' error", "missing required symbol 'db.host'")
+	} else {
+		missingRequiredFieldError, ok := err.(*structproto.MissingRequiredFieldError)
+		if !ok {
+			t.Errorf("the error expected '%T', got '%T'", &structproto.MissingRequiredFieldError{}, err)
+		} else if missingRequiredFieldError.Field != "db.host" {
+			t.Errorf("assert 'MissingRequiredFieldError.Field':: expected '%v', got '%v'", "db.host", missingRequiredFieldError.Field)
+		}
+	}
+}
+
+func TestStruct_Bind_WithNestedStructPointer(t *testing.T) {
+	type (
+		CacheConfig struct {
+			Host string `demo:"host"`
+		}
+		model struct {
+			Name  string       `demo:"name"`
+			Cache *CacheConfig `demo:"cache"`
+			Proxy *CacheConfig `demo:"proxy"`
+		}
+	)
+
+	binder := &MapBinder{
+		values: map[string]string{
+			"name":       "luffy",
+			"cache.host": "redis",
+		},
+	}
+
+	s := model{}
+
+	prototype, err := structproto.Prototypify(&s, &structproto.StructProtoResolveOption{
+		TagName:              "demo",
+		ResolveNestedStructs: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = prototype.Bind(binder)
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected := model{
+		Name: "luffy",
+		Cache: &CacheConfig{
+			Host: "redis",
+		},
+	}
+	if !reflect.DeepEqual(expected, s) {
+		t.Errorf("assert:: expected '%+v', got '%+v'", expected, s)
+	}
+}
+
+func TestStructProtoResolver_WithNestedFlagOnNonStruct(t *testing.T) {
+	s := struct {
+		Name string `demo:"name,nested"`
+	}{}
+
+	_, err := structproto.Prototypify(&s, &structproto.StructProtoResolveOption{
+		TagName: "demo",
+	})
+	if err == nil {
+		t.Errorf("the 'Prototypify()' should throw error on non-struct field with flag '%s'", structproto.NestedFlag)
+	}
+}
+
+func TestStructProtoResolver_WithResolveNestedStructs(t *testing.T) {
+	type (
+		Node struct {
+			Value string `demo:"value"`
+			Next  *Node  `demo:"next"`
+		}
+		model struct {
+			CreatedAt time.Time `demo:"created_at"`
+			Head      Node      `demo:"head"`
+		}
+	)
+
+	s := model{}
+
+	prototype, err := structproto.Prototypify(&s, &structproto.StructProtoResolveOption{
+		TagName:              "demo",
+		ResolveNestedStructs: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	prototype.Visit(func(name string, rv reflect.Value, info structproto.FieldInfo) {
+		names = append(names, name)
+	})
+	sort.Strings(names)
+
+	expectedNames := []string{"created_at", "head.next", "head.value"}
+	if !reflect.DeepEqual(expectedNames, names) {
+		t.Errorf("assert 'Visit()' names:: expected '%#v', got '%#v'", expectedNames, names)
+	}
+}
+
+type (
+	BaseArgs struct {
+		TraceID string `demo:"*TRACE_ID"`
+		Source  string `demo:"SOURCE"`
+	}
+	AuditArgs struct {
+		Source string `demo:"SOURCE"`
+		User   string `demo:"USER"`
+	}
+)
+
+func TestStruct_BindMap_WithEmbeddedStruct(t *testing.T) {
+	type (
+		model struct {
+			BaseArgs
+			*AuditArgs
+			Name string `demo:"NAME"`
+			User string `demo:"USER"`
+		}
+	)
+
+	s := model{}
+
+	prototype, err := structproto.Prototypify(&s, &structproto.StructProtoResolveOption{
+		TagName: "demo",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	prototype.Visit(func(name string, rv reflect.Value, info structproto.FieldInfo) {
+		names = append(names, name)
+	})
+	sort.Strings(names)
+	// SOURCE is ambiguous, USER is shadowed by the outer field
+	expectedNames := []string{"NAME", "TRACE_ID", "USER"}
+	if !reflect.DeepEqual(expectedNames, names) {
+		t.Errorf("assert 'Visit()' names:: expected '%#v', got '%#v'", expectedNames, names)
+	}
+
+	err = prototype.BindMap(map[string]interface{}{
+		"TRACE_ID": "0af7651916cd43dd",
+		"SOURCE":   "gateway",
+		"NAME":     "luffy",
+		"USER":     "admin",
+	}, valuebinder.BuildStringBinder)
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected := model{
+		BaseArgs: BaseArgs{
+			TraceID: "0af7651916cd43dd",
+		},
+		Name: "luffy",
+		User: "admin",
+	}
+	if !reflect.DeepEqual(expected, s) {
+		t.Errorf("assert:: expected '%+v', got '%+v'", expected, s)
+	}
+}
+
+func TestStruct_BindMap_WithEmbeddedStructPointer(t *testing.T) {
+	type (
+		model struct {
+			*AuditArgs
+			Name string `demo:"NAME"`
+		}
+	)
+
+	s := model{}
+
+	prototype, err := structproto.Prototypify(&s, &structproto.StructProtoResolveOption{
+		TagName: "demo",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = prototype.BindMap(map[string]interface{}{
+		"USER": "admin",
+	}, valuebinder.BuildStringBinder)
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected := model{
+		AuditArgs: &AuditArgs{
+			User: "admin",
+		},
+	}
+	if !reflect.DeepEqual(expected, s) {
+		t.Errorf("assert:: expected '%+v', got '%+v'", expected, s)
+	}
+
+	prototype.Visit(func(name string, rv reflect.Value, info structproto.FieldInfo) {
+		if name == "USER" {
+			expectedIndexPath := []int{0, 1}
+			if !reflect.DeepEqual(expectedIndexPath, info.IndexPath()) {
+				t.Errorf("assert 'FieldInfo.IndexPath()':: expected '%#v', got '%#v'", expectedIndexPath, info.IndexPath())
+			}
+			if rv.String() != "admin" {
+				t.Errorf("assert 'Visit()' value:: expected '%#v', got '%#v'", "admin", rv.String())
+			}
+		}
+	})
+}
+
+func TestStructProtoResolver_WithEmbeddedStructAndNoneTagResolver(t *testing.T) {
+	type (
+		model struct {
+			BaseArgs
+			Name string
+		}
+	)
+
+	s := model{}
+
+	prototype, err := structproto.Prototypify(&s, &structproto.StructProtoResolveOption{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = prototype.BindMap(map[string]interface{}{
+		"TraceID": "0af7651916cd43dd",
+		"Name":    "luffy",
+	}, valuebinder.BuildStringBinder)
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected := model{
+		BaseArgs: BaseArgs{
+			TraceID: "0af7651916cd43dd",
+		},
+		Name: "luffy",
+	}
+	if !reflect.DeepEqual(expected, s) {
+		t.Errorf("assert:: expected '%+v', got '%+v'", expected, s)
+	}
+}
+
+func TestStruct_BindMap_WithUnexportedFields(t *testing.T) {
+	type (
+		auditArgs struct {
+			User   string
+			secret string
+		}
+		model struct {
+			*auditArgs
+			Name     string
+			password string
+		}
+	)
+
+	{
+		s := model{}
+
+		prototype, err := structproto.Prototypify(&s, &structproto.StructProtoResolveOption{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = prototype.BindMap(map[string]interface{}{
+			"User":     "admin",
+			"Name":     "luffy",
+			"password": "p@ssw0rd",
+			"secret":   "s3cr3t",
+		}, valuebinder.BuildStringBinder)
+		if err != nil {
+			t.Error(err)
+		}
+
+		expected := model{
+			Name: "luffy",
+		}
+		if !reflect.DeepEqual(expected, s) {
+			t.Errorf("assert:: expected '%+v', got '%+v'", expected, s)
+		}
+	}
+
+	{
+		s := model{}
+
+		_, err := structproto.Prototypify(&s, &structproto.StructProtoResolveOption{
+			UnexportedFields: structproto.RejectUnexportedField,
+		})
+		if err == nil {
+			t.Errorf("the 'Prototypify()' should throw error on unexported field")
+		}
+	}
+
+	{
+		s := model{}
+
+		prototype, err := structproto.Prototypify(&s, &structproto.StructProtoResolveOption{
+			UnexportedFields: structproto.UnsafeBindUnexportedField,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = prototype.BindMap(map[string]interface{}{
+			"User":     "admin",
+			"Name":     "luffy",
+			"password": "p@ssw0rd",
+			"secret":   "s3cr3t",
+		}, valuebinder.BuildStringBinder)
+		if err != nil {
+			t.Error(err)
+		}
+
+		expected := model{
+			auditArgs: &auditArgs{
+				User:   "admin",
+				secret: "s3cr3t",
+			},
+			Name:     "luffy",
+			password: "p@ssw0rd",
+		}
+		if !reflect.DeepEqual(expected, s) {
+			t.Errorf("assert:: expected '%+v', got '%+v'", expected, s)
+		}
+
+		visitData := make(map[string]interface{})
+		prototype.Visit(func(name string, rv reflect.Value, info structproto.FieldInfo) {
+			visitData[name] = rv.Interface()
+		})
+		if visitData["password"] != "p@ssw0rd" {
+			t.Errorf("assert 'Visit()' value:: expected '%#v', got '%#v'", "p@ssw0rd", visitData["password"])
+		}
+	}
+}
+
+func TestStruct_Visit_FieldOrder(t *testing.T) {
+	type (
+		model struct {
+			Name        string    `demo:"*NAME"`
+			Age         *int      `demo:"*AGE"`
+			BaseArgs              // TRACE_ID, SOURCE
+			Alias       []string  `demo:"ALIAS"`
+			DateOfBirth time.Time `demo:"DATE_OF_BIRTH"`
+		}
+	)
+
+	s := model{}
+
+	{
+		prototype, err := structproto.Prototypify(&s, &structproto.StructProtoResolveOption{
+			TagName: "demo",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for i := 0; i < 10; i++ {
+			var names []string
+			prototype.Visit(func(name string, rv reflect.Value, info structproto.FieldInfo) {
+				names = append(names, name)
+			})
+			expectedNames := []string{"NAME", "AGE", "TRACE_ID", "SOURCE", "ALIAS", "DATE_OF_BIRTH"}
+			if !reflect.DeepEqual(expectedNames, names) {
+				t.Fatalf("assert 'Visit()' names:: expected '%#v', got '%#v'", expectedNames, names)
+			}
+		}
+
+		var names []string
+		err = prototype.Map(func(field structproto.FieldInfo, rv reflect.Value) error {
+			names = append(names, field.Name())
+			return nil
+		})
+		if err != nil {
+			t.Error(err)
+		}
+		expectedNames := []string{"NAME", "AGE", "TRACE_ID", "SOURCE", "ALIAS", "DATE_OF_BIRTH"}
+		if !reflect.DeepEqual(expectedNames, names) {
+			t.Errorf("assert 'Map()' names:: expected '%#v', got '%#v'", expectedNames, names)
+		}
+	}
+
+	{
+		prototype, err := structproto.Prototypify(&s, &structproto.StructProtoResolveOption{
+			TagName:    "demo",
+			FieldOrder: structproto.NameFieldOrder,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var names []string
+		prototype.Visit(func(name string, rv reflect.Value, info structproto.FieldInfo) {
+			names = append(names, name)
+		})
+		expectedNames := []string{"AGE", "ALIAS", "DATE_OF_BIRTH", "NAME", "SOURCE", "TRACE_ID"}
+		if !reflect.DeepEqual(expectedNames, names) {
+			t.Errorf("assert 'Visit()' names:: expected '%#v', got '%#v'", expectedNames, names)
+		}
+	}
+}
+
+func TestStruct_BindMap_MissingRequiredFieldInDeclarationOrder(t *testing.T) {
+	s := struct {
+		Name string `demo:"*NAME"`
+		Age  *int   `demo:"*AGE"`
+	}{}
+
+	prototype, err := structproto.Prototypify(&s, &structproto.StructProtoResolveOption{
+		TagName: "demo",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = prototype.BindMap(map[string]interface{}{}, valuebinder.BuildStringBinder)
+	if err == nil {
+		t.Errorf("the 'BindMap()' should throw '%s' error", "missing required symbol 'NAME'")
+	} else {
+		missingRequiredFieldError, ok := err.(*structproto.MissingRequiredFieldError)
+		if !ok {
+			t.Errorf("the error expected '%T', got '%T'", &structproto.MissingRequiredFieldError{}, err)
+		} else if missingRequiredFieldError.Field != "NAME" {
+			t.Errorf("assert 'MissingRequiredFieldError.Field':: expected '%v', got '%v'", "NAME", missingRequiredFieldError.Field)
+		}
+	}
+}
+
+func TestStruct_BindMap_WithDefaultValue(t *testing.T) {
+	type (
+		model struct {
+			Host    string        `demo:"HOST,default=localhost"`
+			Port    int           `demo:"PORT,required,default=8080"`
+			Timeout time.Duration `demo:"TIMEOUT,default=30s"`
+			Tags    []string      `demo:"TAGS,default=a"`
+			Remark  string        `demo:"REMARK"`
+		}
+	)
+
+	s := model{}
+
+	prototype, err := structproto.Prototypify(&s, &structproto.StructProtoResolveOption{
+		TagName: "demo",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = prototype.BindMap(map[string]interface{}{
+		"HOST": "127.0.0.1",
+	}, valuebinder.BuildStringBinder)
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected := model{
+		Host:    "127.0.0.1",
+		Port:    8080,
+		Timeout: 30 * time.Second,
+		Tags:    []string{"a"},
+	}
+	if !reflect.DeepEqual(expected, s) {
+		t.Errorf("assert:: expected '%+v', got '%+v'", expected, s)
+	}
+
+	prototype.Visit(func(name string, rv reflect.Value, info structproto.FieldInfo) {
+		if name == "PORT" {
+			v, ok := info.Default()
+			if !ok || v != "8080" {
+				t.Errorf("assert 'FieldInfo.Default()':: expected '%v', got '%v'", "8080", v)
+			}
+		}
+	})
+}
+
+func TestStructProtoResolver_WithInvalidDefaultValue(t *testing.T) {
+	s := struct {
+		Port int `demo:"PORT,default=http"`
+	}{}
+
+	_, err := structproto.Prototypify(&s, &structproto.StructProtoResolveOption{
+		TagName: "demo",
+	})
+	if err == nil {
+		t.Errorf("the 'Prototypify()' should throw error on invalid default value")
+	}
+}
+
+func TestStruct_BindMap_WithKeyMatching(t *testing.T) {
+	type (
+		model struct {
+			Host string `demo:"*DB_HOST"`
+			Port int    `demo:"DB_PORT"`
+		}
+	)
+
+	values := map[string]interface{}{
+		"db_host": "localhost",
+		"Db-Port": "5432",
+	}
+
+	{
+		s := model{}
+
+		prototype, err := structproto.Prototypify(&s, &structproto.StructProtoResolveOption{
+			TagName:     "demo",
+			KeyMatching: structproto.CaseInsensitiveKeyMatching,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = prototype.BindMap(values, valuebinder.BuildStringBinder)
+		if err != nil {
+			t.Error(err)
+		}
+		expected := model{
+			Host: "localhost",
+		}
+		if !reflect.DeepEqual(expected, s) {
+			t.Errorf("assert:: expected '%+v', got '%+v'", expected, s)
+		}
+	}
+
+	{
+		s := model{}
+
+		prototype, err := structproto.Prototypify(&s, &structproto.StructProtoResolveOption{
+			TagName:     "demo",
+			KeyMatching: structproto.NormalizedKeyMatching,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = prototype.BindMap(values, valuebinder.BuildStringBinder)
+		if err != nil {
+			t.Error(err)
+		}
+		expected := model{
+			Host: "localhost",
+			Port: 5432,
+		}
+		if !reflect.DeepEqual(expected, s) {
+			t.Errorf("assert:: expected '%+v', got '%+v'", expected, s)
+		}
+	}
+
+	{
+		s := model{}
+
+		prototype, err := structproto.Prototypify(&s, &structproto.StructProtoResolveOption{
+			TagName: "demo",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = prototype.BindMap(values, valuebinder.BuildStringBinder)
+		if err == nil {
+			t.Errorf("the 'BindMap()' should throw '%s' error", "missing required symbol 'DB_HOST'")
+		}
+	}
+}
+
+func TestStructProtoResolver_CheckDuplicateNamesWithKeyMatching(t *testing.T) {
+	s := struct {
+		Host     string `demo:"DB_HOST"`
+		HostName string `demo:"db-host"`
+	}{}
+
+	_, err := structproto.Prototypify(&s, &structproto.StructProtoResolveOption{
+		TagName:             "demo",
+		CheckDuplicateNames: true,
+	})
+	if err != nil {
+		t.Error(err)
+	}
+
+	_, err = structproto.Prototypify(&s, &structproto.StructProtoResolveOption{
+		TagName:             "demo",
+		CheckDuplicateNames: true,
+		KeyMatching:         structproto.NormalizedKeyMatching,
+	})
+	if err == nil {
+		t.Errorf("the 'Prototypify()' should throw error on duplicate names")
+	}
+}
+
+func TestStruct_BindFields_WithAliases(t *testing.T) {
+	type (
+		model struct {
+			Host string `demo:"*DB_HOST|DATABASE_HOST"`
+			Port int    `demo:"DB_PORT|DATABASE_PORT"`
+		}
+	)
+
+	var deprecated []string
+	option := &structproto.StructProtoResolveOption{
+		TagName: "demo",
+		OnDeprecatedAlias: func(field structproto.FieldInfo, alias string) {
+			deprecated = append(deprecated, alias)
+		},
+	}
+
+	{
+		s := model{}
+
+		prototype, err := structproto.Prototypify(&s, option)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = prototype.BindFields([]structproto.FieldValueEntity{
+			{Field: "DATABASE_HOST", Value: "localhost"},
+			{Field: "DB_PORT", Value: "5432"},
+		}, valuebinder.BuildStringBinder)
+		if err != nil {
+			t.Error(err)
+		}
+		expected := model{
+			Host: "localhost",
+			Port: 5432,
+		}
+		if !reflect.DeepEqual(expected, s) {
+			t.Errorf("assert:: expected '%+v', got '%+v'", expected, s)
+		}
+		expectedDeprecated := []string{"DATABASE_HOST"}
+		if !reflect.DeepEqual(expectedDeprecated, deprecated) {
+			t.Errorf("assert 'OnDeprecatedAlias':: expected '%#v', got '%#v'", expectedDeprecated, deprecated)
+		}
+
+		// the alias is not reported if its value fails to bind
+		deprecated = nil
+		err = prototype.BindFields([]structproto.FieldValueEntity{
+			{Field: "DB_HOST", Value: "localhost"},
+			{Field: "DATABASE_PORT", Value: "unknown"},
+		}, valuebinder.BuildStringBinder)
+		if err == nil {
+			t.Errorf("assert 'BindFields()':: expected error")
+		}
+		if len(deprecated) != 0 {
+			t.Errorf("assert 'OnDeprecatedAlias':: expected no alias, got '%#v'", deprecated)
+		}
+		deprecated = nil
+
+		prototype.Visit(func(name string, rv reflect.Value, info structproto.FieldInfo) {
+			if name == "DB_HOST" {
+				expectedAliases := []string{"DATABASE_HOST"}
+				if !reflect.DeepEqual(expectedAliases, info.Aliases()) {
+					t.Errorf("assert 'FieldInfo.Aliases()':: expected '%#v', got '%#v'", expectedAliases, info.Aliases())
+				}
+			}
+		})
+	}
+
+	values := []structproto.FieldValueEntity{
+		{Field: "DB_HOST", Value: "localhost"},
+		{Field: "DATABASE_HOST", Value: "127.0.0.1"},
+	}
+
+	{
+		s := model{}
+
+		prototype, err := structproto.Prototypify(&s, &structproto.StructProtoResolveOption{
+			TagName: "demo",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = prototype.BindFields(values, valuebinder.BuildStringBinder)
+		if err != nil {
+			t.Error(err)
+		}
+		if s.Host != "127.0.0.1" {
+			t.Errorf("assert 'Host':: expected '%v', got '%v'", "127.0.0.1", s.Host)
+		}
+	}
+
+	{
+		s := model{}
+
+		prototype, err := structproto.Prototypify(&s, &structproto.StructProtoResolveOption{
+			TagName:        "demo",
+			AliasConflicts: structproto.PrimaryAliasWins,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = prototype.BindFields(values, valuebinder.BuildStringBinder)
+		if err != nil {
+			t.Error(err)
+		}
+		if s.Host != "localhost" {
+			t.Errorf("assert 'Host':: expected '%v', got '%v'", "localhost", s.Host)
+		}
+	}
+
+	{
+		s := model{}
+
+		prototype, err := structproto.Prototypify(&s, &structproto.StructProtoResolveOption{
+			TagName:        "demo",
+			AliasConflicts: structproto.RejectAliasConflict,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = prototype.BindFields(values, valuebinder.BuildStringBinder)
+		if _, ok := err.(*structproto.FieldBindingError); !ok {
+			t.Errorf("the error expected '%T', got '%T'", &structproto.FieldBindingError{}, err)
+		}
+	}
+}
+
+func TestStruct_BindMap_WithNamingStrategy(t *testing.T) {
+	type (
+		model struct {
+			MaxConns int
+			HTTPPort int
+			UserID   string
+		}
+		taggedModel struct {
+			MaxConns int    `demo:",required"`
+			HTTPPort int    `demo:"PORT"`
+			UserID   string `demo:",required"`
+		}
+	)
+
+	{
+		s := model{}
+
+		prototype, err := structproto.Prototypify(&s, &structproto.StructProtoResolveOption{
+			NamingStrategy: naming.ScreamingSnakeCase,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = prototype.BindMap(map[string]interface{}{
+			"MAX_CONNS": "16",
+			"HTTP_PORT": "8080",
+			"USER_ID":   "luffy",
+		}, valuebinder.BuildStringBinder)
+		if err != nil {
+			t.Error(err)
+		}
+		expected := model{
+			MaxConns: 16,
+			HTTPPort: 8080,
+			UserID:   "luffy",
+		}
+		if !reflect.DeepEqual(expected, s) {
+			t.Errorf("assert:: expected '%+v', got '%+v'", expected, s)
+		}
+	}
+
+	{
+		s := taggedModel{}
+
+		prototype, err := structproto.Prototypify(&s, &structproto.StructProtoResolveOption{
+			TagName:        "demo",
+			NamingStrategy: naming.SnakeCase,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = prototype.BindMap(map[string]interface{}{
+			"max_conns": "16",
+			"PORT":      "8080",
+			"user_id":   "luffy",
+		}, valuebinder.BuildStringBinder)
+		if err != nil {
+			t.Error(err)
+		}
+		expected := taggedModel{
+			MaxConns: 16,
+			HTTPPort: 8080,
+			UserID:   "luffy",
+		}
+		if !reflect.DeepEqual(expected, s) {
+			t.Errorf("assert:: expected '%+v', got '%+v'", expected, s)
+		}
+	}
+
+	// the same type resolved with another strategy should not share the
+	// cached schema
+	{
+		s := model{}
+
+		prototype, err := structproto.Prototypify(&s, &structproto.StructProtoResolveOption{
+			NamingStrategy: naming.KebabCase,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = prototype.BindMap(map[string]interface{}{
+			"max-conns": "32",
+		}, valuebinder.BuildStringBinder)
+		if err != nil {
+			t.Error(err)
+		}
+		expected := model{
+			MaxConns: 32,
+		}
+		if !reflect.DeepEqual(expected, s) {
+			t.Errorf("assert:: expected '%+v', got '%+v'", expected, s)
+		}
+	}
+}
+
+func TestStruct_BindMap_WithTagNames(t *testing.T) {
+	type (
+		model struct {
+			Host    string `json:"host"`
+			Port    int    `json:"port,omitempty" env:"*DB_PORT"`
+			Timeout int    `json:",omitempty"`
+			Secret  string `json:"-"`
+			Remark  string
+		}
+	)
+
+	s := model{}
+
+	prototype, err := structproto.Prototypify(&s, &structproto.StructProtoResolveOption{
+		TagName:  "env",
+		TagNames: []string{"json"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = prototype.BindMap(map[string]interface{}{
+		"host":    "localhost",
+		"DB_PORT": "5432",
+		"Timeout": "30",
+		"Secret":  "s3cr3t",
+		"Remark":  "none",
+	}, valuebinder.BuildStringBinder)
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected := model{
+		Host:    "localhost",
+		Port:    5432,
+		Timeout: 30,
+	}
+	if !reflect.DeepEqual(expected, s) {
+		t.Errorf("assert:: expected '%+v', got '%+v'", expected, s)
+	}
+
+	expectedTagNames := map[string]string{
+		"host":    "json",
+		"DB_PORT": "env",
+		"Timeout": "json",
+	}
+	tagNames := make(map[string]string)
+	prototype.Visit(func(name string, rv reflect.Value, info structproto.FieldInfo) {
+		tagNames[name] = info.TagName()
+	})
+	if !reflect.DeepEqual(expectedTagNames, tagNames) {
+		t.Errorf("assert 'FieldInfo.TagName()':: expected '%#v', got '%#v'", expectedTagNames, tagNames)
+	}
+
+	err = prototype.BindMap(map[string]interface{}{}, valuebinder.BuildStringBinder)
+	if err == nil {
+		t.Errorf("the 'BindMap()' should throw '%s' error", "missing required symbol 'DB_PORT'")
+	}
+}
+
+func TestStructProtoResolver_StrictTags(t *testing.T) {
+	type (
+		typoFlagModel struct {
+			Name string `demo:"NAME,requried"`
+		}
+		requiredPrefixModel struct {
+			Name string `demo:"*NAME,required"`
+		}
+		unknownAttrModel struct {
+			Tags []string `demo:"TAGS,separator=;"`
+		}
+		conflictAttrModel struct {
+			CreatedAt time.Time `demo:"CREATED_AT,layout=DateOnly,unix=ms"`
+		}
+	)
+
+	option := &structproto.StructProtoResolveOption{
+		TagName:    "demo",
+		StrictTags: true,
+	}
+
+	var cases = []struct {
+		target interface{}
+		field  string
+		token  string
+	}{
+		{&typoFlagModel{}, "Name", "requried"},
+		{&requiredPrefixModel{}, "Name", "*NAME"},
+		{&unknownAttrModel{}, "Tags", "separator"},
+		{&conflictAttrModel{}, "CreatedAt", "unix"},
+	}
+	for _, c := range cases {
+		_, err := structproto.Prototypify(c.target, option)
+		var e *structproto.TagValidationError
+		if !errors.As(err, &e) {
+			t.Errorf("assert 'errors.As()':: expected *TagValidationError, got '%v'", err)
+			continue
+		}
+		if e.Field != c.field {
+			t.Errorf("assert 'TagValidationError.Field':: expected '%v', got '%v'", c.field, e.Field)
+		}
+		if e.Token != c.token {
+			t.Errorf("assert 'TagValidationError.Token':: expected '%v', got '%v'", c.token, e.Token)
+		}
+		if e.Type != reflect.TypeOf(c.target).Elem() {
+			t.Errorf("assert 'TagValidationError.Type':: expected '%v', got '%v'", reflect.TypeOf(c.target).Elem(), e.Type)
+		}
+	}
+
+	// the tags are accepted without StrictTags
+	_, err := structproto.Prototypify(&typoFlagModel{}, &structproto.StructProtoResolveOption{
+		TagName: "demo",
+	})
+	if err != nil {
+		t.Errorf("assert 'Prototypify()':: expected no error, got '%v'", err)
+	}
+}
+
+func TestStruct_BindFields_CollectBindingErrors(t *testing.T) {
+	type model struct {
+		Name string `demo:"*NAME"`
+		Age  int    `demo:"*AGE"`
+		Port int    `demo:"PORT,default=8080"`
+		Host string `demo:"*HOST"`
+		Rate int    `demo:"RATE"`
+	}
+
+	s := model{}
+	prototype, err := structproto.Prototypify(&s, &structproto.StructProtoResolveOption{
+		TagName:              "demo",
+		CollectBindingErrors: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = prototype.BindFields([]structproto.FieldValueEntity{
+		{Field: "RATE", Value: "fast"},
+		{Field: "PORT", Value: "http"},
+		{Field: "AGE", Value: "old"},
+	}, valuebinder.BuildStringBinder)
+
+	var errs structproto.BindingErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("assert 'errors.As()':: expected BindingErrors, got '%v'", err)
+	}
+	var expectedFields = []string{"NAME", "AGE", "PORT", "HOST", "RATE"}
+	if len(errs) != len(expectedFields) {
+		t.Fatalf("assert 'len(BindingErrors)':: expected '%v', got '%v'", len(expectedFields), len(errs))
+	}
+	for i, e := range errs {
+		var field string
+		switch e := e.(type) {
+		case *structproto.FieldBindingError:
+			field = e.Field
+		case *structproto.MissingRequiredFieldError:
+			field = e.Field
+		default:
+			t.Errorf("assert 'BindingErrors[%d]':: unexpected error type %T", i, e)
+		}
+		if field != expectedFields[i] {
+			t.Errorf("assert 'BindingErrors[%d]':: expected '%v', got '%v'", i, expectedFields[i], field)
+		}
+	}
+
+	var missing *structproto.MissingRequiredFieldError
+	if !errors.As(err, &missing) {
+		t.Errorf("assert 'errors.As()':: expected *MissingRequiredFieldError")
+	}
+	var failure *structproto.FieldBindingError
+	if !errors.As(err, &failure) {
+		t.Errorf("assert 'errors.As()':: expected *FieldBindingError")
+	}
+	if s.Port != 0 {
+		t.Errorf("assert 'model.Port':: expected '%v', got '%v'", 0, s.Port)
+	}
+
+	// stop at the first error without CollectBindingErrors
+	prototype, err = structproto.Prototypify(&s, &structproto.StructProtoResolveOption{
+		TagName: "demo",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = prototype.BindMap(map[string]interface{}{
+		"RATE": "fast",
+	}, valuebinder.BuildStringBinder)
+	if _, ok := err.(*structproto.FieldBindingError); !ok {
+		t.Errorf("assert 'BindMap()':: expected *FieldBindingError, got '%v'", err)
+	}
+
+	// no error if everything is bound
+	prototype, err = structproto.Prototypify(&s, &structproto.StructProtoResolveOption{
+		TagName:              "demo",
+		CollectBindingErrors: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = prototype.BindMap(map[string]interface{}{
+		"NAME": "luffy",
+		"AGE":  "19",
+		"HOST": "localhost",
+	}, valuebinder.BuildStringBinder)
+	if err != nil {
+		t.Errorf("assert 'BindMap()':: expected no error, got '%v'", err)
+	}
+}
+
+func TestStruct_BindFields_WithAtomicBinding(t *testing.T) {
+	type (
+		database struct {
+			Host string `demo:"HOST"`
+		}
+		model struct {
+			Name  string    `demo:"NAME"`
+			Count *int      `demo:"COUNT"`
+			DB    *database `demo:"DB"`
+			Port  int       `demo:"PORT"`
+		}
+	)
+
+	var (
+		count = 1
+		db    = &database{Host: "localhost"}
+		s     = model{
+			Name:  "origin",
+			Count: &count,
+			DB:    db,
+			Port:  80,
+		}
+	)
+
+	prototype, err := structproto.Prototypify(&s, &structproto.StructProtoResolveOption{
+		TagName:              "demo",
+		ResolveNestedStructs: true,
+		AtomicBinding:        true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = prototype.BindFields([]structproto.FieldValueEntity{
+		{Field: "NAME", Value: "luffy"},
+		{Field: "COUNT", Value: "5"},
+		{Field: "DB.HOST", Value: "127.0.0.1"},
+		{Field: "PORT", Value: "http"},
+	}, valuebinder.BuildStringBinder)
+	if err == nil {
+		t.Fatalf("assert 'BindFields()':: expected error")
+	}
+	expected := model{
+		Name:  "origin",
+		Count: &count,
+		DB:    db,
+		Port:  80,
+	}
+	if !reflect.DeepEqual(expected, s) {
+		t.Errorf("assert:: expected '%+v', got '%+v'", expected, s)
+	}
+	if count != 1 {
+		t.Errorf("assert 'model.Count':: expected '%v', got '%v'", 1, count)
+	}
+	if db.Host != "localhost" {
+		t.Errorf("assert 'model.DB.Host':: expected '%v', got '%v'", "localhost", db.Host)
+	}
+
+	err = prototype.BindFields([]structproto.FieldValueEntity{
+		{Field: "NAME", Value: "luffy"},
+		{Field: "COUNT", Value: "5"},
+		{Field: "DB.HOST", Value: "127.0.0.1"},
+		{Field: "PORT", Value: "8080"},
+	}, valuebinder.BuildStringBinder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = model{
+		Name:  "luffy",
+		Count: pointy.Int(5),
+		DB:    &database{Host: "127.0.0.1"},
+		Port:  8080,
+	}
+	if !reflect.DeepEqual(expected, s) {
+		t.Errorf("assert:: expected '%+v', got '%+v'", expected, s)
+	}
+	if count != 1 {
+		t.Errorf("assert 'count':: expected '%v', got '%v'", 1, count)
+	}
+}
+
+func TestStruct_Bind_WithAtomicBinding(t *testing.T) {
+	type model struct {
+		Name string `demo:"*NAME"`
+		Age  int    `demo:"*AGE"`
+	}
+
+	s := model{Name: "origin", Age: 1}
+	prototype, err := structproto.Prototypify(&s, &structproto.StructProtoResolveOption{
+		TagName:       "demo",
+		AtomicBinding: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the Deinit fails on missing required field AGE
+	err = prototype.Bind(&MapBinder{
+		values: map[string]string{
+			"NAME": "luffy",
+		},
+	})
+	if err == nil {
+		t.Fatalf("assert 'Bind()':: expected error")
+	}
+	expected := model{Name: "origin", Age: 1}
+	if !reflect.DeepEqual(expected, s) {
+		t.Errorf("assert:: expected '%+v', got '%+v'", expected, s)
+	}
+
+	err = prototype.Bind(&MapBinder{
+		values: map[string]string{
+			"NAME": "luffy",
+			"AGE":  "19",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = model{Name: "luffy", Age: 19}
+	if !reflect.DeepEqual(expected, s) {
+		t.Errorf("assert:: expected '%+v', got '%+v'", expected, s)
+	}
+}
+
+func TestStruct_BindMap_WithoutGoroutineLeak(t *testing.T) {
+	type model struct {
+		Port int `demo:"PORT"`
+	}
+
+	values := map[string]interface{}{
+		"PORT": "http",
+	}
+	for i := 0; i < 64; i++ {
+		values[fmt.Sprintf("KEY_%d", i)] = "value"
+	}
+
+	baseline := runtime.NumGoroutine()
+	for i := 0; i < 16; i++ {
+		s := model{}
+		prototype, err := structproto.Prototypify(&s, &structproto.StructProtoResolveOption{
+			TagName: "demo",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = prototype.BindMap(values, valuebinder.BuildStringBinder)
+		if err == nil {
+			t.Fatalf("assert 'BindMap()':: expected error")
+		}
+		err = prototype.BindIterator(EntitySet{{"PORT", "http"}, {"NAME", "luffy"}}, valuebinder.BuildStringBinder)
+		if err == nil {
+			t.Fatalf("assert 'BindIterator()':: expected error")
+		}
+	}
+
+	// the panic in binding should not leave the goroutine behind
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("assert 'BindMap()':: expected panic")
+			}
+		}()
+		s := model{}
+		prototype, err := structproto.Prototypify(&s, &structproto.StructProtoResolveOption{
+			TagName: "demo",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = prototype.BindMap(values, func(rv reflect.Value) structproto.ValueBinder {
+			panic("unexpected")
+		})
+	}()
+
+	if n := waitGoroutines(baseline); n > baseline {
+		t.Errorf("assert 'runtime.NumGoroutine()':: expected '%v', got '%v'", baseline, n)
+	}
+}
+
+func TestStruct_BindChanContext(t *testing.T) {
+	type model struct {
+		Name string `demo:"NAME"`
+	}
+
+	s := model{}
+	prototype, err := structproto.Prototypify(&s, &structproto.StructProtoResolveOption{
+		TagName: "demo",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c := make(chan structproto.FieldValueEntity)
+	go func() {
+		c <- structproto.FieldValueEntity{Field: "NAME", Value: "luffy"}
+		cancel()
+	}()
+	err = prototype.BindChanContext(ctx, c, valuebinder.BuildStringBinder)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("assert 'BindChanContext()':: expected '%v', got '%v'", context.Canceled, err)
+	}
+	if s.Name != "luffy" {
+		t.Errorf("assert 'model.Name':: expected '%v', got '%v'", "luffy", s.Name)
+	}
+
+	// the iterator stops once the ctx is done
+	baseline := runtime.NumGoroutine()
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	err = prototype.BindIteratorContext(ctx, ContextEntitySet{{"NAME", "zoro"}, {"NAME", "nami"}}, valuebinder.BuildStringBinder)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("assert 'BindIteratorContext()':: expected '%v', got '%v'", context.Canceled, err)
+	}
+	// hide the Seq of FieldValueMap to bind through the channel
+	iterator := struct {
+		structproto.ContextIterator
+	}{
+		structproto.FieldValueMap{"NAME": "zoro", "AGE": "21", "ALIAS": "hunter"},
+	}
+	err = prototype.BindIteratorContext(ctx, iterator, valuebinder.BuildStringBinder)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("assert 'BindIteratorContext()':: expected '%v', got '%v'", context.Canceled, err)
+	}
+	if n := waitGoroutines(baseline); n > baseline {
+		t.Errorf("assert 'runtime.NumGoroutine()':: expected '%v', got '%v'", baseline, n)
+	}
+}
+
+func TestStruct_BindChanContext_ClosedOnCancel(t *testing.T) {
+	type model struct {
+		Name string `demo:"*NAME"`
+	}
+
+	// the iterator is closed right after the ctx is done, as the
+	// ContextIterator does, the binding must not be reported as success
+	for i := 0; i < 100; i++ {
+		s := model{}
+		prototype, err := structproto.Prototypify(&s, &structproto.StructProtoResolveOption{
+			TagName:       "demo",
+			AtomicBinding: true,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		c := make(chan structproto.FieldValueEntity)
+		go func() {
+			c <- structproto.FieldValueEntity{Field: "NAME", Value: "luffy"}
+			cancel()
+			close(c)
+		}()
+		err = prototype.BindChanContext(ctx, c, valuebinder.BuildStringBinder)
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("assert 'BindChanContext()':: expected '%v', got '%v'", context.Canceled, err)
+		}
+		if s.Name != "" {
+			t.Fatalf("assert 'model.Name':: expected '%v', got '%v'", "", s.Name)
+		}
+	}
+}
+
+func TestStruct_BindSeq(t *testing.T) {
+	type model struct {
+		Name string `demo:"*NAME"`
+		Age  int    `demo:"AGE"`
+	}
+
+	s := model{}
+	prototype, err := structproto.Prototypify(&s, &structproto.StructProtoResolveOption{
+		TagName: "demo",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var yielded int
+	err = prototype.BindSeq(func(yield func(structproto.FieldValueEntity) bool) {
+		for _, v := range (EntitySet{{"NAME", "luffy"}, {"AGE", "young"}, {"AGE", "19"}}) {
+			yielded++
+			if !yield(structproto.FieldValueEntity{Field: v[0], Value: v[1]}) {
+				return
+			}
+		}
+	}, valuebinder.BuildStringBinder)
+	if _, ok := err.(*structproto.FieldBindingError); !ok {
+		t.Errorf("assert 'BindSeq()':: expected *FieldBindingError, got '%v'", err)
+	}
+	if yielded != 2 {
+		t.Errorf("assert 'yielded':: expected '%v', got '%v'", 2, yielded)
+	}
+
+	err = prototype.BindIterator(SeqEntitySet{{"NAME", "luffy"}, {"AGE", "19"}}, valuebinder.BuildStringBinder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := model{Name: "luffy", Age: 19}
+	if !reflect.DeepEqual(expected, s) {
+		t.Errorf("assert:: expected '%+v', got '%+v'", expected, s)
+	}
+}
+
+func TestStruct_BindFields_WithUnknownKeys(t *testing.T) {
+	type model struct {
+		DatabaseURL string `demo:"DATABASE_URL"`
+		Name        string `demo:"*NAME|USERNAME"`
+		Port        int    `demo:"PORT"`
+	}
+
+	values := []structproto.FieldValueEntity{
+		{Field: "DATABSE_URL", Value: "postgres://localhost"},
+		{Field: "USERNAEM", Value: "luffy"},
+		{Field: "COLOR", Value: "red"},
+		{Field: "PORT", Value: "5432"},
+	}
+
+	{
+		s := model{}
+		prototype, err := structproto.Prototypify(&s, &structproto.StructProtoResolveOption{
+			TagName:     "demo",
+			UnknownKeys: structproto.RejectUnknownKey,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = prototype.BindFields(values, valuebinder.BuildStringBinder)
+		var e *structproto.UnknownKeyError
+		if !errors.As(err, &e) {
+			t.Fatalf("assert 'errors.As()':: expected *UnknownKeyError, got '%v'", err)
+		}
+		expected := []structproto.UnknownKey{
+			{Key: "DATABSE_URL", Suggestions: []string{"DATABASE_URL"}},
+			{Key: "USERNAEM", Suggestions: []string{"USERNAME"}},
+			{Key: "COLOR"},
+		}
+		if !reflect.DeepEqual(expected, e.Keys) {
+			t.Errorf("assert 'UnknownKeyError.Keys':: expected '%+v', got '%+v'", expected, e.Keys)
+		}
+		expectedMessage := "unknown keys 'DATABSE_URL' (did you mean 'DATABASE_URL'?), 'USERNAEM' (did you mean 'USERNAME'?), 'COLOR'"
+		if e.Error() != expectedMessage {
+			t.Errorf("assert 'UnknownKeyError.Error()':: expected '%v', got '%v'", expectedMessage, e.Error())
+		}
+	}
+
+	{
+		var warned *structproto.UnknownKeyError
+
+		s := model{}
+		prototype, err := structproto.Prototypify(&s, &structproto.StructProtoResolveOption{
+			TagName:     "demo",
+			UnknownKeys: structproto.WarnUnknownKey,
+			OnUnknownKeys: func(err *structproto.UnknownKeyError) {
+				warned = err
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = prototype.BindFields(append(values, structproto.FieldValueEntity{Field: "NAME", Value: "luffy"}), valuebinder.BuildStringBinder)
+		if err != nil {
+			t.Errorf("assert 'BindFields()':: expected no error, got '%v'", err)
+		}
+		if warned == nil || len(warned.Keys) != 3 {
+			t.Errorf("assert 'OnUnknownKeys':: expected 3 unknown keys, got '%v'", warned)
+		}
+		if s.Port != 5432 {
+			t.Errorf("assert 'model.Port':: expected '%v', got '%v'", 5432, s.Port)
+		}
+	}
+
+	{
+		s := model{}
+		prototype, err := structproto.Prototypify(&s, &structproto.StructProtoResolveOption{
+			TagName:              "demo",
+			UnknownKeys:          structproto.RejectUnknownKey,
+			CollectBindingErrors: true,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = prototype.BindFields(values, valuebinder.BuildStringBinder)
+		var errs structproto.BindingErrors
+		if !errors.As(err, &errs) {
+			t.Fatalf("assert 'errors.As()':: expected BindingErrors, got '%v'", err)
+		}
+		if len(errs) != 2 {
+			t.Fatalf("assert 'len(BindingErrors)':: expected '%v', got '%v'", 2, len(errs))
+		}
+		if _, ok := errs[0].(*structproto.UnknownKeyError); !ok {
+			t.Errorf("assert 'BindingErrors[0]':: expected *UnknownKeyError, got '%v'", errs[0])
+		}
+		if _, ok := errs[1].(*structproto.MissingRequiredFieldError); !ok {
+			t.Errorf("assert 'BindingErrors[1]':: expected *MissingRequiredFieldError, got '%v'", errs[1])
+		}
+	}
+}
+
+func TestStruct_BindMap_WithRemainField(t *testing.T) {
+	type (
+		model struct {
+			Name    string                 `demo:"NAME"`
+			Headers map[string]interface{} `demo:",remain"`
+		}
+		stringModel struct {
+			Name    string            `demo:"NAME"`
+			Headers map[string]string `demo:"HEADERS,remain"`
+		}
+		intModel struct {
+			Name   string         `demo:"NAME"`
+			Counts map[string]int `demo:",remain"`
+		}
+	)
+
+	{
+		s := model{}
+		prototype, err := structproto.Prototypify(&s, &structproto.StructProtoResolveOption{
+			TagName:     "demo",
+			UnknownKeys: structproto.RejectUnknownKey,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = prototype.BindMap(map[string]interface{}{
+			"NAME":       "luffy",
+			"X-TRACE-ID": "abc",
+			"Headers":    "self",
+		}, valuebinder.BuildStringBinder)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := model{
+			Name: "luffy",
+			Headers: map[string]interface{}{
+				"X-TRACE-ID": "abc",
+				"Headers":    "self",
+			},
+		}
+		if !reflect.DeepEqual(expected, s) {
+			t.Errorf("assert:: expected '%+v', got '%+v'", expected, s)
+		}
+	}
+
+	{
+		s := stringModel{
+			Headers: map[string]string{"X-ORIGIN": "keep"},
+		}
+		prototype, err := structproto.Prototypify(&s, &structproto.StructProtoResolveOption{
+			TagName: "demo",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = prototype.BindFields([]structproto.FieldValueEntity{
+			{Field: "NAME", Value: "luffy"},
+			{Field: "X-TRACE-ID", Value: "abc"},
+		}, valuebinder.BuildStringBinder)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := stringModel{
+			Name: "luffy",
+			Headers: map[string]string{
+				"X-ORIGIN":   "keep",
+				"X-TRACE-ID": "abc",
+			},
+		}
+		if !reflect.DeepEqual(expected, s) {
+			t.Errorf("assert:: expected '%+v', got '%+v'", expected, s)
+		}
+	}
+
+	{
+		s := intModel{}
+		prototype, err := structproto.Prototypify(&s, &structproto.StructProtoResolveOption{
+			TagName: "demo",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = prototype.BindFields([]structproto.FieldValueEntity{
+			{Field: "RETRY", Value: "3"},
+			{Field: "COLOR", Value: "red"},
+		}, valuebinder.BuildStringBinder)
+		if _, ok := err.(*structproto.FieldBindingError); !ok {
+			t.Errorf("assert 'BindFields()':: expected *FieldBindingError, got '%v'", err)
+		}
+		if s.Counts["RETRY"] != 3 {
+			t.Errorf("assert 'intModel.Counts[RETRY]':: expected '%v', got '%v'", 3, s.Counts["RETRY"])
+		}
+	}
+
+	// the map of target is intact if the atomic binding fails
+	{
+		s := intModel{
+			Counts: map[string]int{"ORIGIN": 1},
+		}
+		prototype, err := structproto.Prototypify(&s, &structproto.StructProtoResolveOption{
+			TagName:       "demo",
+			AtomicBinding: true,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = prototype.BindFields([]structproto.FieldValueEntity{
+			{Field: "RETRY", Value: "3"},
+			{Field: "COLOR", Value: "red"},
+		}, valuebinder.BuildStringBinder)
+		if err == nil {
+			t.Errorf("assert 'BindFields()':: expected error")
+		}
+		expected := map[string]int{"ORIGIN": 1}
+		if !reflect.DeepEqual(expected, s.Counts) {
+			t.Errorf("assert 'intModel.Counts':: expected '%+v', got '%+v'", expected, s.Counts)
+		}
+	}
+}
+
+func TestStructProtoResolver_InvalidRemainField(t *testing.T) {
+	type (
+		sliceModel struct {
+			Headers []string `demo:",remain"`
+		}
+		keyModel struct {
+			Headers map[int]string `demo:",remain"`
+		}
+		duplicateModel struct {
+			Headers map[string]string `demo:",remain"`
+			Extras  map[string]string `demo:",remain"`
+		}
+	)
+
+	for _, target := range []interface{}{&sliceModel{}, &keyModel{}, &duplicateModel{}} {
+		_, err := structproto.Prototypify(target, &structproto.StructProtoResolveOption{
+			TagName: "demo",
+		})
+		if err == nil {
+			t.Errorf("assert 'Prototypify(%T)':: expected error", target)
+		}
+	}
+}
+
+func TestStruct_BindFields_WithRepeatedKeys(t *testing.T) {
+	type model struct {
+		Name   string   `demo:"NAME"`
+		Host   string   `demo:"HOST,repeat=first"`
+		Token  string   `demo:"TOKEN,repeat=reject"`
+		Tags   []string `demo:"TAGS,repeat=accumulate"`
+		Labels []string `demo:"LABELS"`
+	}
+
+	{
+		s := model{
+			Tags: []string{"origin"},
+		}
+		prototype, err := structproto.Prototypify(&s, &structproto.StructProtoResolveOption{
+			TagName: "demo",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = prototype.BindFields([]structproto.FieldValueEntity{
+			{Field: "NAME", Value: "luffy"},
+			{Field: "NAME", Value: "zoro"},
+			{Field: "HOST", Value: "localhost"},
+			{Field: "HOST", Value: "127.0.0.1"},
+			{Field: "TOKEN", Value: "abc"},
+			{Field: "TAGS", Value: "a"},
+			{Field: "TAGS", Value: "b,c"},
+			{Field: "LABELS", Value: "x"},
+			{Field: "LABELS", Value: "y"},
+		}, valuebinder.BuildStringBinder)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := model{
+			Name:   "zoro",
+			Host:   "localhost",
+			Token:  "abc",
+			Tags:   []string{"a", "b", "c"},
+			Labels: []string{"y"},
+		}
+		if !reflect.DeepEqual(expected, s) {
+			t.Errorf("assert:: expected '%+v', got '%+v'", expected, s)
+		}
+
+		err = prototype.BindFields([]structproto.FieldValueEntity{
+			{Field: "TOKEN", Value: "abc"},
+			{Field: "TOKEN", Value: "def"},
+		}, valuebinder.BuildStringBinder)
+		if _, ok := err.(*structproto.FieldBindingError); !ok {
+			t.Errorf("assert 'BindFields()':: expected *FieldBindingError, got '%v'", err)
+		}
+	}
+
+	// the resolver default applies to the fields without repeat attribute
+	{
+		s := model{}
+		prototype, err := structproto.Prototypify(&s, &structproto.StructProtoResolveOption{
+			TagName:      "demo",
+			RepeatedKeys: structproto.AccumulateRepeatedKey,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = prototype.BindFields([]structproto.FieldValueEntity{
+			{Field: "NAME", Value: "luffy"},
+			{Field: "NAME", Value: "zoro"},
+			{Field: "LABELS", Value: "x"},
+			{Field: "LABELS", Value: "y"},
+		}, valuebinder.BuildStringBinder)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := model{
+			Name:   "zoro",
+			Labels: []string{"x", "y"},
+		}
+		if !reflect.DeepEqual(expected, s) {
+			t.Errorf("assert:: expected '%+v', got '%+v'", expected, s)
+		}
+	}
+}
+
+func TestStructProtoResolver_InvalidRepeatAttr(t *testing.T) {
+	type (
+		accumulateModel struct {
+			Name string `demo:"NAME,repeat=accumulate"`
+		}
+		unknownModel struct {
+			Name string `demo:"NAME,repeat=never"`
+		}
+	)
+
+	for _, target := range []interface{}{&accumulateModel{}, &unknownModel{}} {
+		_, err := structproto.Prototypify(target, &structproto.StructProtoResolveOption{
+			TagName: "demo",
+		})
+		if err == nil {
+			t.Errorf("assert 'Prototypify(%T)':: expected error", target)
+		}
+	}
+}
+
+func TestStruct_BindMap_WithTimeAttrs(t *testing.T) {
+	type model struct {
+		Date      time.Time   `demo:"DATE,layout=2006/01/02"`
+		LocalTime time.Time   `demo:"LOCAL_TIME,layout=DateTime,tz=Asia/Taipei"`
+		Timestamp time.Time   `demo:"TIMESTAMP,unix=ms"`
+		Seconds   *time.Time  `demo:"SECONDS"`
+		History   []time.Time `demo:"HISTORY,layout=DateOnly"`
+		Expires   time.Time   `demo:"EXPIRES,layout=DateOnly,default=2099-12-31"`
+	}
+
+	taipei, err := time.LoadLocation("Asia/Taipei")
+	if err != nil {
+		t.Skip(err)
+	}
+
+	s := model{}
+	prototype, err := structproto.Prototypify(&s, &structproto.StructProtoResolveOption{
+		TagName:    "demo",
+		StrictTags: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = prototype.BindMap(map[string]interface{}{
+		"DATE":       "2024/01/02",
+		"LOCAL_TIME": "2024-01-02 08:00:00",
+		"TIMESTAMP":  "1704153600123",
+		"SECONDS":    "2024-01-02T00:00:00Z",
+		"HISTORY":    "2023-12-31,2024-01-01",
+	}, valuebinder.BuildStringBinder)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var cases = []struct {
+		name     string
+		expected time.Time
+		actual   time.Time
+	}{
+		{"Date", time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), s.Date},
+		{"LocalTime", time.Date(2024, 1, 2, 8, 0, 0, 0, taipei), s.LocalTime},
+		{"Timestamp", time.Date(2024, 1, 2, 0, 0, 0, 123000000, time.UTC), s.Timestamp},
+		{"Seconds", time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), *s.Seconds},
+		{"History[0]", time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC), s.History[0]},
+		{"History[1]", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), s.History[1]},
+		{"Expires", time.Date(2099, 12, 31, 0, 0, 0, 0, time.UTC), s.Expires},
+	}
+	for _, c := range cases {
+		if !c.expected.Equal(c.actual) {
+			t.Errorf("assert 'model.%s':: expected '%v', got '%v'", c.name, c.expected, c.actual)
+		}
+	}
+	if s.LocalTime.Location().String() != taipei.String() {
+		t.Errorf("assert 'model.LocalTime.Location()':: expected '%v', got '%v'", taipei, s.LocalTime.Location())
+	}
+
+	// the default value is validated by the attributes of the field
+	_, err = structproto.Prototypify(&struct {
+		Expires time.Time `demo:"EXPIRES,layout=DateOnly,default=2099/12/31"`
+	}{}, &structproto.StructProtoResolveOption{
+		TagName: "demo",
+	})
+	if err == nil {
+		t.Errorf("assert 'Prototypify()':: expected error")
+	}
+}
+
+func TestStruct_BindMap_WithUnixTime(t *testing.T) {
+	type model struct {
+		Created time.Time `demo:"CREATED"`
+		Updated time.Time `demo:"UPDATED,unix=us,tz=UTC"`
+	}
+
+	s := model{}
+	prototype, err := structproto.Prototypify(&s, &structproto.StructProtoResolveOption{
+		TagName: "demo",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = prototype.BindMap(map[string]interface{}{
+		"CREATED": 1704153600,
+		"UPDATED": int64(1704153600000001),
+	}, valuebinder.BuildScalarBinder)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := model{
+		Created: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		Updated: time.Date(2024, 1, 2, 0, 0, 0, 1000, time.UTC),
+	}
+	if !reflect.DeepEqual(expected, s) {
+		t.Errorf("assert:: expected '%+v', got '%+v'", expected, s)
+	}
+}
+
+func TestStruct_BindMap_WithListAttrs(t *testing.T) {
+	type model struct {
+		Tags    []string `demo:"TAGS,quote=true"`
+		Paths   []string `demo:"PATHS,sep=':'"`
+		Ports   []int    `demo:"PORTS,trim=false"`
+		Aliases []string `demo:"ALIASES,default=' a | b '"`
+	}
+
+	{
+		s := model{}
+		prototype, err := structproto.Prototypify(&s, &structproto.StructProtoResolveOption{
+			TagName:    "demo",
+			StrictTags: true,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = prototype.BindMap(map[string]interface{}{
+			"TAGS":  `"a,b",c`,
+			"PATHS": "/usr/bin:/bin",
+			"PORTS": "80,443",
+		}, valuebinder.BuildStringBinder)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := model{
+			Tags:    []string{"a,b", "c"},
+			Paths:   []string{"/usr/bin", "/bin"},
+			Ports:   []int{80, 443},
+			Aliases: []string{" a | b "},
+		}
+		if !reflect.DeepEqual(expected, s) {
+			t.Errorf("assert:: expected '%+v', got '%+v'", expected, s)
+		}
+	}
+
+	// the resolver defaults apply to the fields without the attributes
+	{
+		s := model{}
+		prototype, err := structproto.Prototypify(&s, &structproto.StructProtoResolveOption{
+			TagName:          "demo",
+			ListSeparator:    "|",
+			TrimListElements: true,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = prototype.BindMap(map[string]interface{}{
+			"TAGS":  " a | b ",
+			"PATHS": " /usr/bin : /bin ",
+			"PORTS": "80|443",
+		}, valuebinder.BuildStringBinder)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := model{
+			Tags:    []string{"a", "b"},
+			Paths:   []string{"/usr/bin", "/bin"},
+			Ports:   []int{80, 443},
+			Aliases: []string{"a", "b"},
+		}
+		if !reflect.DeepEqual(expected, s) {
+			t.Errorf("assert:: expected '%+v', got '%+v'", expected, s)
+		}
+	}
+}
+
+func TestStruct_BindMap_WithListQuoting(t *testing.T) {
+	type model struct {
+		Tags []string `demo:"TAGS"`
+	}
+
+	// the double quotes are ordinary characters by default
+	{
+		s := model{}
+		prototype, err := structproto.Prototypify(&s, &structproto.StructProtoResolveOption{
+			TagName: "demo",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = prototype.BindMap(map[string]interface{}{
+			"TAGS": `"a,b`,
+		}, valuebinder.BuildStringBinder)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := []string{`"a`, "b"}
+		if !reflect.DeepEqual(expected, s.Tags) {
+			t.Errorf("assert 'model.Tags':: expected '%#v', got '%#v'", expected, s.Tags)
+		}
+	}
+
+	{
+		s := model{}
+		prototype, err := structproto.Prototypify(&s, &structproto.StructProtoResolveOption{
+			TagName:           "demo",
+			QuoteListElements: true,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = prototype.BindMap(map[string]interface{}{
+			"TAGS": `"a,b",c`,
+		}, valuebinder.BuildStringBinder)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := []string{"a,b", "c"}
+		if !reflect.DeepEqual(expected, s.Tags) {
+			t.Errorf("assert 'model.Tags':: expected '%#v', got '%#v'", expected, s.Tags)
+		}
+	}
+}
+
+func TestStruct_BindMap_WithNestedStructPointerUnbound(t *testing.T) {
+	type (
+		DBConfig struct {
+			Host string `demo:"host"`
+			Port int    `demo:"port"`
+		}
+		model struct {
+			Name string    `demo:"name"`
+			DB   *DBConfig `demo:"db,nested"`
+		}
+	)
+
+	// the pointer is not allocated if the value fails to bind
+	{
+		s := model{}
+		prototype, err := structproto.Prototypify(&s, &structproto.StructProtoResolveOption{
+			TagName:              "demo",
+			CollectBindingErrors: true,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = prototype.BindMap(map[string]interface{}{
+			"name":    "luffy",
+			"db.port": "unknown",
+		}, valuebinder.BuildStringBinder)
+		if err == nil {
+			t.Errorf("assert 'BindMap()':: expected error")
+		}
+		if s.DB != nil {
+			t.Errorf("assert 'model.DB':: expected '%v', got '%+v'", nil, s.DB)
+		}
+	}
+
+	// the pointer is not allocated if no ValueBinder is provided
+	{
+		s := model{}
+		prototype, err := structproto.Prototypify(&s, &structproto.StructProtoResolveOption{
+			TagName: "demo",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = prototype.BindMap(map[string]interface{}{
+			"db.host": "localhost",
+		}, valuebinder.BuildIgnoreBinder)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s.DB != nil {
+			t.Errorf("assert 'model.DB':: expected '%v', got '%+v'", nil, s.DB)
+		}
+	}
+
+	// the pointer is allocated once a field is bound, even by a zero value
+	{
+		s := model{}
+		prototype, err := structproto.Prototypify(&s, &structproto.StructProtoResolveOption{
+			TagName: "demo",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = prototype.BindMap(map[string]interface{}{
+			"db.port": "0",
+		}, valuebinder.BuildStringBinder)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := &DBConfig{}
+		if !reflect.DeepEqual(expected, s.DB) {
+			t.Errorf("assert 'model.DB':: expected '%+v', got '%+v'", expected, s.DB)
+		}
+	}
+}
+
+func TestStruct_BindMap_WithDefaultValueAndBytesBinder(t *testing.T) {
+	type model struct {
+		Name  string   `demo:"NAME"`
+		Port  *int     `demo:"PORT,default=8080"`
+		Hosts []string `demo:"HOSTS,default='a,b'"`
+	}
+
+	var a, b model
+	pa, err := structproto.Prototypify(&a, &structproto.StructProtoResolveOption{
+		TagName: "demo",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the default values are converted at resolve time, the ValueBindProvider
+	// of the call needs not accept string
+	err = pa.BindMap(map[string]interface{}{
+		"NAME": []byte("luffy"),
+	}, valuebinder.BuildBytesBinder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := model{
+		Name:  "luffy",
+		Port:  pointy.Int(8080),
+		Hosts: []string{"a", "b"},
+	}
+	if !reflect.DeepEqual(expected, a) {
+		t.Errorf("assert:: expected '%+v', got '%+v'", expected, a)
+	}
+
+	// the bound structs do not share the default values
+	*a.Port = 80
+	a.Hosts[0] = "x"
+
+	pb, err := structproto.Prototypify(&b, &structproto.StructProtoResolveOption{
+		TagName: "demo",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = pb.BindMap(map[string]interface{}{}, valuebinder.BuildBytesBinder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected.Name = ""
+	if !reflect.DeepEqual(expected, b) {
+		t.Errorf("assert:: expected '%+v', got '%+v'", expected, b)
+	}
+}
+
+func TestStruct_BindMap_WithKnownTypesNotNested(t *testing.T) {
+	type (
+		coordinate struct {
+			Lat, Lng float64
+		}
+		model struct {
+			Cidr     *net.IPNet
+			Contact  mail.Address
+			Location coordinate
+		}
+	)
+
+	valuebinder.RegisterConverter(reflect.TypeOf(coordinate{}), func(rv reflect.Value, v interface{}) error {
+		var c coordinate
+		_, err := fmt.Sscanf(v.(string), "%f,%f", &c.Lat, &c.Lng)
+		if err != nil {
+			return err
+		}
+		rv.Set(reflect.ValueOf(c))
+		return nil
+	})
+	defer valuebinder.DefaultConverters.Unregister(reflect.TypeOf(coordinate{}))
+	defer structproto.PurgeCache()
+
+	s := model{}
+	prototype, err := structproto.Prototypify(&s, &structproto.StructProtoResolveOption{
+		ResolveNestedStructs: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = prototype.BindMap(map[string]interface{}{
+		"Cidr":     "10.1.2.3/16",
+		"Contact":  "Luffy <luffy@example.com>",
+		"Location": "25.03,121.56",
+	}, valuebinder.BuildStringBinder)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s.Cidr == nil || s.Cidr.String() != "10.1.0.0/16" {
+		t.Errorf("assert 'model.Cidr':: expected '%v', got '%v'", "10.1.0.0/16", s.Cidr)
+	}
+	if s.Contact.Address != "luffy@example.com" {
+		t.Errorf("assert 'model.Contact':: expected '%v', got '%v'", "luffy@example.com", s.Contact.Address)
+	}
+	expected := coordinate{25.03, 121.56}
+	if s.Location != expected {
+		t.Errorf("assert 'model.Location':: expected '%v', got '%v'", expected, s.Location)
+	}
+}
@@ -0,0 +1,135 @@
+package structproto
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/Bofry/structproto/common"
+)
+
+var (
+	// tagRegistryVersion is increased on every registration, the schemas
+	// resolved with StrictTags are cached by it, so they are validated
+	// again against the registered tokens.
+	tagRegistryVersion atomic.Uint64
+
+	defaultTagRegistry = &tagRegistry{
+		flags: map[string]bool{
+			common.RequiredFlag: true,
+			common.NestedFlag:   true,
+			common.RemainFlag:   true,
+			common.BlankFlag:    true,
+			// the options of tag json
+			"omitempty": true,
+			"string":    true,
+		},
+		attrs: map[string]bool{
+			common.DefaultAttr: true,
+			common.RepeatAttr:  true,
+			// the attributes of time.Time
+			common.LayoutAttr:   true,
+			common.TimeZoneAttr: true,
+			common.UnixAttr:     true,
+			// the attributes of lists
+			common.SepAttr:   true,
+			common.TrimAttr:  true,
+			common.QuoteAttr: true,
+		},
+		conflicts: [][2]string{
+			{common.LayoutAttr, common.UnixAttr},
+		},
+	}
+)
+
+type tagRegistry struct {
+	mutex     sync.RWMutex
+	flags     map[string]bool
+	attrs     map[string]bool
+	conflicts [][2]string
+}
+
+func (r *tagRegistry) registerFlags(flags ...string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, v := range flags {
+		r.flags[v] = true
+	}
+	tagRegistryVersion.Add(1)
+}
+
+func (r *tagRegistry) registerAttrs(keys ...string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, v := range keys {
+		r.attrs[v] = true
+	}
+	tagRegistryVersion.Add(1)
+}
+
+func (r *tagRegistry) registerConflict(x, y string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.conflicts = append(r.conflicts, [2]string{x, y})
+	tagRegistryVersion.Add(1)
+}
+
+func (r *tagRegistry) hasFlag(flag string) bool {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	return r.flags[flag]
+}
+
+func (r *tagRegistry) hasAttr(key string) bool {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	return r.attrs[key]
+}
+
+// findConflict returns the first pair of the flags or attribute keys of
+// tag which cannot be declared together.
+func (r *tagRegistry) findConflict(tag *Tag) (string, string, bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	for _, pair := range r.conflicts {
+		if tagHasToken(tag, pair[0]) && tagHasToken(tag, pair[1]) {
+			return pair[0], pair[1], true
+		}
+	}
+	return "", "", false
+}
+
+func tagHasToken(tag *Tag, token string) bool {
+	if _, ok := tag.Attrs[token]; ok {
+		return true
+	}
+	for _, v := range tag.Flags {
+		if v == token {
+			return true
+		}
+	}
+	return false
+}
+
+// RegisterTagFlags registers the flags which are accepted by the resolver
+// with StructProtoResolveOption.StrictTags.
+func RegisterTagFlags(flags ...string) {
+	defaultTagRegistry.registerFlags(flags...)
+}
+
+// RegisterTagAttrs registers the attribute keys which are accepted by the
+// resolver with StructProtoResolveOption.StrictTags.
+func RegisterTagAttrs(keys ...string) {
+	defaultTagRegistry.registerAttrs(keys...)
+}
+
+// RegisterTagConflict registers two flags or attribute keys which cannot
+// be declared on the same field with StructProtoResolveOption.StrictTags.
+func RegisterTagConflict(x, y string) {
+	defaultTagRegistry.registerConflict(x, y)
+}
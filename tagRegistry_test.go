@@ -0,0 +1,67 @@
+package structproto
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTagRegistry(t *testing.T) {
+	type (
+		conflictModel struct {
+			Name string `demo:"NAME,secret,mask=1"`
+		}
+		registeredModel struct {
+			Name string `demo:"NAME|LEGACY_NAME,required,secret,mask=*;the name"`
+			Port int    `demo:"PORT,default=80"`
+		}
+	)
+
+	// register on a copy of the default registry
+	origin := defaultTagRegistry
+	defaultTagRegistry = &tagRegistry{
+		flags: make(map[string]bool),
+		attrs: make(map[string]bool),
+	}
+	for k := range origin.flags {
+		defaultTagRegistry.flags[k] = true
+	}
+	for k := range origin.attrs {
+		defaultTagRegistry.attrs[k] = true
+	}
+	defaultTagRegistry.conflicts = append(defaultTagRegistry.conflicts, origin.conflicts...)
+	defer func() {
+		defaultTagRegistry = origin
+		PurgeCache()
+	}()
+
+	option := &StructProtoResolveOption{
+		TagName:    "demo",
+		StrictTags: true,
+	}
+
+	_, err := Prototypify(&registeredModel{}, option)
+	if err == nil {
+		t.Errorf("assert 'Prototypify()':: expected error on unregistered flag")
+	}
+	RegisterTagFlags("secret")
+	RegisterTagAttrs("mask")
+	_, err = Prototypify(&registeredModel{}, option)
+	if err != nil {
+		t.Errorf("assert 'Prototypify()':: expected no error, got '%v'", err)
+	}
+
+	_, err = Prototypify(&conflictModel{}, option)
+	if err != nil {
+		t.Errorf("assert 'Prototypify()':: expected no error, got '%v'", err)
+	}
+	// the schema cached before the registration is validated again
+	RegisterTagConflict("secret", "mask")
+	_, err = Prototypify(&conflictModel{}, option)
+	var e *TagValidationError
+	if !errors.As(err, &e) {
+		t.Fatalf("assert 'errors.As()':: expected *TagValidationError, got '%v'", err)
+	}
+	if e.Token != "mask" {
+		t.Errorf("assert 'TagValidationError.Token':: expected '%v', got '%v'", "mask", e.Token)
+	}
+}
@@ -0,0 +1,24 @@
+package structproto
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// A TagValidationError represents an error when a tag is rejected by the
+// resolver with StructProtoResolveOption.StrictTags.
+type TagValidationError struct {
+	Type  reflect.Type
+	Field string
+	Token string
+	Err   error
+}
+
+func (e *TagValidationError) Error() string {
+	return fmt.Sprintf("invalid tag token '%s' on field '%s' of type %s. %+v", e.Token, e.Field, e.Type, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *TagValidationError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,37 @@
+package tagresolver
+
+import (
+	"strings"
+
+	"github.com/Bofry/structproto/common"
+)
+
+var _ common.TagResolver = JsonTagResolver
+
+// JsonTagResolver resolves the token in the syntax of encoding/json, e.g.
+// `json:"name,omitempty"`. The options such as omitempty are kept as
+// flags.
+func JsonTagResolver(fieldname, token string) (*common.Tag, error) {
+	if len(token) > 0 {
+		if token == "-" {
+			return nil, nil
+		}
+
+		parts := strings.Split(token, ",")
+		name, flags := parts[0], parts[1:]
+		if len(name) == 0 {
+			name = fieldname
+		}
+
+		tag := &common.Tag{
+			Name: name,
+		}
+		for _, flag := range flags {
+			if len(flag) > 0 {
+				tag.Flags = append(tag.Flags, flag)
+			}
+		}
+		return tag, nil
+	}
+	return nil, nil
+}
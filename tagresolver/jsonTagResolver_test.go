@@ -0,0 +1,55 @@
+package tagresolver
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestJsonTagResolver(t *testing.T) {
+	{
+		tag, err := JsonTagResolver("name", "real_name,omitempty")
+		if err != nil {
+			t.Errorf("should not error, but got %v", err)
+		} else {
+			var expectedName string = "real_name"
+			if tag.Name != expectedName {
+				t.Errorf("assert Tag.Name expected '%+v', got '%+v'", expectedName, tag.Name)
+			}
+			var expectedFlags []string = []string{"omitempty"}
+			if !reflect.DeepEqual(tag.Flags, expectedFlags) {
+				t.Errorf("assert Tag.Flags expected '%+v', got '%+v'", expectedFlags, tag.Flags)
+			}
+		}
+	}
+	{
+		tag, err := JsonTagResolver("name", ",omitempty")
+		if err != nil {
+			t.Errorf("should not error, but got %v", err)
+		} else {
+			var expectedName string = "name"
+			if tag.Name != expectedName {
+				t.Errorf("assert Tag.Name expected '%+v', got '%+v'", expectedName, tag.Name)
+			}
+		}
+	}
+	{
+		tag, err := JsonTagResolver("name", "-")
+		if err != nil {
+			t.Errorf("should not error, but got %v", err)
+		}
+		if tag != nil {
+			t.Errorf("assert Tag expected nil, got '%+v'", tag)
+		}
+	}
+	{
+		tag, err := JsonTagResolver("name", "-,")
+		if err != nil {
+			t.Errorf("should not error, but got %v", err)
+		} else {
+			var expectedName string = "-"
+			if tag.Name != expectedName {
+				t.Errorf("assert Tag.Name expected '%+v', got '%+v'", expectedName, tag.Name)
+			}
+		}
+	}
+}
@@ -9,16 +9,27 @@ import (
 
 var _ common.TagResolver = StdTagResolver
 
+// StdTagResolver resolves the token in the form of
+//
+//	name[|alias...][,flag|,key=value...][;description]
+//
+// The ',' and ';' in the attribute values can be escaped by '\' or
+// enclosed in quotes, e.g. sep=';' or sep=\;.
 func StdTagResolver(fieldname, token string) (*common.Tag, error) {
 	if len(token) > 0 {
-		parts := strings.SplitN(token, ";", 2)
+		parts, err := splitToken(token, ';', 2)
+		if err != nil {
+			return nil, err
+		}
 		var desc string
 		if len(parts) == 2 {
-			parts, desc = strings.Split(parts[0], ","), parts[1]
-		} else {
-			parts = strings.Split(token, ",")
+			desc = parts[1]
+		}
+		parts, err = splitToken(parts[0], ',', -1)
+		if err != nil {
+			return nil, err
 		}
-		name, flags := parts[0], parts[1:]
+		name, flags := unquoteToken(parts[0]), parts[1:]
 
 		if len(flags) == 0 {
 			for ii := 0; ii < len(name); ii++ {
@@ -41,12 +52,48 @@ func StdTagResolver(fieldname, token string) (*common.Tag, error) {
 			}
 		}
 
+		var aliases []string
+		if strings.Contains(name, common.AliasSeparator) {
+			names := strings.Split(name, common.AliasSeparator)
+			name = names[0]
+			for _, alias := range names[1:] {
+				if len(alias) > 0 {
+					aliases = append(aliases, alias)
+				}
+			}
+		}
+
+		// use the field name if the token only provides flags
+		if len(name) == 0 && len(flags) > 0 {
+			name = fieldname
+		}
+
 		var tag *common.Tag
 		if len(name) > 0 && name != "-" {
 			tag = &common.Tag{
-				Name:  name,
-				Flags: flags,
-				Desc:  desc,
+				Name:    name,
+				Desc:    desc,
+				Aliases: aliases,
+			}
+			for _, flag := range flags {
+				if i := indexToken(flag, '='); i != -1 {
+					key, value := unquoteToken(flag[:i]), unquoteToken(flag[i+1:])
+					if len(key) == 0 {
+						return nil, fmt.Errorf("missing attribute key in '%s'", flag)
+					}
+					if _, ok := tag.Attrs[key]; ok {
+						return nil, fmt.Errorf("duplicate attribute key '%s'", key)
+					}
+					if tag.Attrs == nil {
+						tag.Attrs = make(map[string]string)
+					}
+					tag.Attrs[key] = value
+					continue
+				}
+				tag.Flags = append(tag.Flags, unquoteToken(flag))
+			}
+			if v, ok := tag.Attrs[common.DefaultAttr]; ok {
+				tag.Default = &v
 			}
 		}
 		return tag, nil
@@ -46,3 +46,102 @@ func TestStdTagResolver(t *testing.T) {
 		}
 	}
 }
+
+func TestStdTagResolver_WithDefault(t *testing.T) {
+	tag, err := StdTagResolver("port", "PORT,required,default=8080;the listening port")
+	if err != nil {
+		t.Errorf("should not error, but got %v", err)
+	} else {
+		var expectedName string = "PORT"
+		if tag.Name != expectedName {
+			t.Errorf("assert Tag.Name expected '%+v', got '%+v'", expectedName, tag.Name)
+		}
+		var expectedFlags []string = []string{"required"}
+		if !reflect.DeepEqual(tag.Flags, expectedFlags) {
+			t.Errorf("assert Tag.Flags expected '%+v', got '%+v'", expectedFlags, tag.Flags)
+		}
+		var expectedDefault string = "8080"
+		if tag.Default == nil || *tag.Default != expectedDefault {
+			t.Errorf("assert Tag.Default expected '%+v', got '%+v'", expectedDefault, tag.Default)
+		}
+	}
+}
+
+func TestStdTagResolver_WithAliases(t *testing.T) {
+	tag, err := StdTagResolver("host", "*DB_HOST|DATABASE_HOST||HOST")
+	if err != nil {
+		t.Errorf("should not error, but got %v", err)
+	} else {
+		var expectedName string = "DB_HOST"
+		if tag.Name != expectedName {
+			t.Errorf("assert Tag.Name expected '%+v', got '%+v'", expectedName, tag.Name)
+		}
+		var expectedAliases []string = []string{"DATABASE_HOST", "HOST"}
+		if !reflect.DeepEqual(tag.Aliases, expectedAliases) {
+			t.Errorf("assert Tag.Aliases expected '%+v', got '%+v'", expectedAliases, tag.Aliases)
+		}
+		var expectedFlags []string = []string{"required"}
+		if !reflect.DeepEqual(tag.Flags, expectedFlags) {
+			t.Errorf("assert Tag.Flags expected '%+v', got '%+v'", expectedFlags, tag.Flags)
+		}
+	}
+}
+
+func TestStdTagResolver_WithFlagsOnly(t *testing.T) {
+	tag, err := StdTagResolver("max_conns", ",required")
+	if err != nil {
+		t.Errorf("should not error, but got %v", err)
+	} else {
+		var expectedName string = "max_conns"
+		if tag.Name != expectedName {
+			t.Errorf("assert Tag.Name expected '%+v', got '%+v'", expectedName, tag.Name)
+		}
+		var expectedFlags []string = []string{"required"}
+		if !reflect.DeepEqual(tag.Flags, expectedFlags) {
+			t.Errorf("assert Tag.Flags expected '%+v', got '%+v'", expectedFlags, tag.Flags)
+		}
+	}
+}
+
+func TestStdTagResolver_WithAttrs(t *testing.T) {
+	tag, err := StdTagResolver("tags", `TAGS,required,sep=';',layout=2006-01-02,quote="a,b",escaped=x\,y\;z,max=10;the tags; separated by ';'`)
+	if err != nil {
+		t.Errorf("should not error, but got %v", err)
+	} else {
+		var expectedName string = "TAGS"
+		if tag.Name != expectedName {
+			t.Errorf("assert Tag.Name expected '%+v', got '%+v'", expectedName, tag.Name)
+		}
+		var expectedFlags []string = []string{"required"}
+		if !reflect.DeepEqual(tag.Flags, expectedFlags) {
+			t.Errorf("assert Tag.Flags expected '%+v', got '%+v'", expectedFlags, tag.Flags)
+		}
+		var expectedAttrs map[string]string = map[string]string{
+			"sep":     ";",
+			"layout":  "2006-01-02",
+			"quote":   "a,b",
+			"escaped": "x,y;z",
+			"max":     "10",
+		}
+		if !reflect.DeepEqual(tag.Attrs, expectedAttrs) {
+			t.Errorf("assert Tag.Attrs expected '%+v', got '%+v'", expectedAttrs, tag.Attrs)
+		}
+		var expectedDesc string = "the tags; separated by ';'"
+		if tag.Desc != expectedDesc {
+			t.Errorf("assert Tag.Desc expected '%+v', got '%+v'", expectedDesc, tag.Desc)
+		}
+	}
+
+	_, err = StdTagResolver("tags", `TAGS,sep=';`)
+	if err == nil {
+		t.Errorf("should error on unterminated quote")
+	}
+	_, err = StdTagResolver("tags", `TAGS,=;`)
+	if err == nil {
+		t.Errorf("should error on missing attribute key")
+	}
+	_, err = StdTagResolver("tags", `TAGS,sep=\;,sep=','`)
+	if err == nil {
+		t.Errorf("should error on duplicate attribute key")
+	}
+}
@@ -0,0 +1,99 @@
+package tagresolver
+
+import (
+	"fmt"
+	"strings"
+)
+
+// splitToken splits token by the separator which is not escaped by '\'
+// or enclosed by quotes ('...' or "..."). The parts keep their quotes
+// and escapes, use unquoteToken to resolve them. If n >= 0, splitToken
+// returns at most n parts.
+func splitToken(token string, sep byte, n int) ([]string, error) {
+	var (
+		parts []string
+		start = 0
+		quote byte
+	)
+
+	for i := 0; i < len(token); i++ {
+		ch := token[i]
+		switch {
+		case ch == '\\':
+			i++
+		case quote != 0:
+			if ch == quote {
+				quote = 0
+			}
+		case ch == '\'' || ch == '"':
+			quote = ch
+		case ch == sep:
+			parts = append(parts, token[start:i])
+			start = i + 1
+			if n >= 0 && len(parts) == n-1 {
+				// the remaining is taken as it is
+				return append(parts, token[start:]), nil
+			}
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote %c in '%s'", quote, token)
+	}
+	return append(parts, token[start:]), nil
+}
+
+// indexToken returns the index of the first ch in token which is not
+// escaped or enclosed by quotes, or -1 if ch is not present.
+func indexToken(token string, ch byte) int {
+	var quote byte
+	for i := 0; i < len(token); i++ {
+		c := token[i]
+		switch {
+		case c == '\\':
+			i++
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == ch:
+			return i
+		}
+	}
+	return -1
+}
+
+// unquoteToken removes the quotes and resolves the escapes of token.
+func unquoteToken(token string) string {
+	if strings.IndexAny(token, `\'"`) == -1 {
+		return token
+	}
+
+	var (
+		sb    strings.Builder
+		quote byte
+	)
+	sb.Grow(len(token))
+	for i := 0; i < len(token); i++ {
+		ch := token[i]
+		switch {
+		case ch == '\\':
+			if i+1 < len(token) {
+				i++
+				sb.WriteByte(token[i])
+			}
+		case quote != 0:
+			if ch == quote {
+				quote = 0
+			} else {
+				sb.WriteByte(ch)
+			}
+		case ch == '\'' || ch == '"':
+			quote = ch
+		default:
+			sb.WriteByte(ch)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,37 @@
+package structproto
+
+import (
+	"fmt"
+	"strings"
+)
+
+// An UnknownKeyError represents an error when the received keys match no
+// field, see StructProtoResolveOption.UnknownKeys.
+type UnknownKeyError struct {
+	Keys []UnknownKey
+}
+
+// UnknownKey is a received key matches no field, with the names of the
+// fields or aliases close to it.
+type UnknownKey struct {
+	Key         string
+	Suggestions []string
+}
+
+func (e *UnknownKeyError) Error() string {
+	var sb strings.Builder
+	sb.WriteString("unknown key")
+	if len(e.Keys) > 1 {
+		sb.WriteString("s")
+	}
+	for i, k := range e.Keys {
+		if i > 0 {
+			sb.WriteString(",")
+		}
+		fmt.Fprintf(&sb, " '%s'", k.Key)
+		if len(k.Suggestions) > 0 {
+			fmt.Fprintf(&sb, " (did you mean '%s'?)", strings.Join(k.Suggestions, "', '"))
+		}
+	}
+	return sb.String()
+}
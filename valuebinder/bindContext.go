@@ -0,0 +1,45 @@
+package valuebinder
+
+import (
+	"reflect"
+
+	"github.com/Bofry/structproto/common"
+)
+
+var (
+	_ common.AttrBinder = new(contextBinder)
+)
+
+// bindContext keeps what the conversions consult besides the value, the
+// converters and the attributes of the field bound.
+type bindContext struct {
+	converters *ConverterRegistry
+	attrs      map[string]string
+}
+
+// assignable reports the value of type from is assigned to the type to as
+// is; the other named types known to the converters are converted, e.g.
+// []byte to net.HardwareAddr.
+func (ctx bindContext) assignable(from, to reflect.Type) bool {
+	if from == to {
+		return true
+	}
+	return from.AssignableTo(to) && !ctx.converters.IsKnownType(to)
+}
+
+type contextBinder struct {
+	rv   reflect.Value
+	ctx  bindContext
+	bind func(rv reflect.Value, input interface{}, ctx bindContext) error
+}
+
+func (binder *contextBinder) Bind(input interface{}) error {
+	return binder.bind(binder.rv, input, binder.ctx)
+}
+
+// WithAttrs implements common.AttrBinder.
+func (binder *contextBinder) WithAttrs(attrs map[string]string) common.ValueBinder {
+	clone := *binder
+	clone.ctx.attrs = attrs
+	return &clone
+}
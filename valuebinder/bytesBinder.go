@@ -6,11 +6,12 @@ import (
 	"reflect"
 
 	"github.com/Bofry/structproto/common"
+	"github.com/Bofry/structproto/reflecting"
 )
 
 var (
 	_ common.ValueBindProvider = BuildBytesBinder
-	_ common.ValueBinder       = new(BytesBinder)
+	_ common.AttrBinder        = new(BytesBinder)
 )
 
 type BytesBinder reflect.Value
@@ -20,21 +21,45 @@ func BuildBytesBinder(rv reflect.Value) common.ValueBinder {
 }
 
 func (binder BytesBinder) Bind(input interface{}) error {
+	return bindBytesInput(reflect.Value(binder), input, bindContext{converters: DefaultConverters})
+}
+
+// WithAttrs implements common.AttrBinder.
+func (binder BytesBinder) WithAttrs(attrs map[string]string) common.ValueBinder {
+	return &contextBinder{
+		rv:   reflect.Value(binder),
+		ctx:  bindContext{converters: DefaultConverters, attrs: attrs},
+		bind: bindBytesInput,
+	}
+}
+
+func bindBytesInput(rv reflect.Value, input interface{}, ctx bindContext) error {
 	buf, ok := input.([]byte)
 	if !ok {
 		return fmt.Errorf("cannot bind type %T from input", input)
 	}
-	rv := reflect.Value(binder)
-	if typeOfBytes.AssignableTo(rv.Type()) {
+	if ctx.assignable(typeOfBytes, rv.Type()) {
 		rv.Set(reflect.ValueOf(buf))
 		return nil
 	}
-	return binder.bindValueImpl(rv, buf)
+	return bindBytes(rv, buf, ctx)
 }
 
-func (binder BytesBinder) bindValueImpl(rv reflect.Value, v []byte) error {
+func bindBytes(rv reflect.Value, v []byte, ctx bindContext) error {
+	if ok, err := bindKnownPointerType(rv, v, ctx); ok {
+		return err
+	}
+
+	rv = indirectVal(reflecting.AssignZero(rv))
 	var err error
 
+	if converter, ok := ctx.converters.Lookup(rv.Type()); ok {
+		return converter(rv, v)
+	}
+	if ok, err := bindBinaryUnmarshaler(rv, v); ok {
+		return err
+	}
+
 	kind := rv.Kind()
 	typ := rv.Type()
 	if kind == reflect.Struct && typ == typeOfBuffer {
@@ -46,7 +71,7 @@ func (binder BytesBinder) bindValueImpl(rv reflect.Value, v []byte) error {
 		rv.Set(reflect.ValueOf(buf))
 	} else {
 		str := string(v)
-		return StringBinder(reflect.Value(binder)).bindValueImpl(rv, str)
+		return bindString(rv, str, ctx)
 	}
 	return err
 }
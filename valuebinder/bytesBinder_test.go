@@ -1,7 +1,15 @@
 package valuebinder
 
 import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"net"
+	"net/netip"
+	"net/url"
+	"os"
 	"reflect"
+	"regexp"
 	"testing"
 	"time"
 )
@@ -104,3 +112,107 @@ func TestBytesBinder_WithIntArray(t *testing.T) {
 		t.Errorf("assert 'v':: expected '%#v', got '%#v'", expected, v)
 	}
 }
+
+func TestBytesBinder_WithHardwareAddr(t *testing.T) {
+	var v net.HardwareAddr
+	var input = []byte("00:00:5e:00:53:01")
+
+	rv := reflect.ValueOf(&v).Elem()
+	binder := BytesBinder(rv)
+	err := binder.Bind(input)
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected := net.HardwareAddr{0x00, 0x00, 0x5e, 0x00, 0x53, 0x01}
+	if !reflect.DeepEqual(v, expected) {
+		t.Errorf("assert 'v':: expected '%#v', got '%#v'", expected, v)
+	}
+
+	err = binder.Bind([]byte("00:00:5e"))
+	if err == nil {
+		t.Errorf("assert 'BytesBinder.Bind()':: expected error, got '%#v'", v)
+	}
+}
+
+func TestBytesBinder_WithKnownTypes(t *testing.T) {
+	var v struct {
+		Month  time.Month
+		Addr   netip.Addr
+		Int    *big.Int
+		Url    *url.URL
+		Mode   os.FileMode
+		Raw    json.RawMessage
+		Regexp *regexp.Regexp
+	}
+	var cases = []struct {
+		field string
+		input string
+	}{
+		{"Month", "March"},
+		{"Addr", "192.0.2.1"},
+		{"Int", "12345678901234567890"},
+		{"Url", "https://example.com/path"},
+		{"Mode", "0644"},
+		{"Raw", `{"name":"luffy"}`},
+		{"Regexp", "^a+$"},
+	}
+	rv := reflect.ValueOf(&v).Elem()
+	for _, c := range cases {
+		err := BytesBinder(rv.FieldByName(c.field)).Bind([]byte(c.input))
+		if err != nil {
+			t.Errorf("assert 'BytesBinder.Bind(%s)':: %v", c.field, err)
+		}
+	}
+
+	bigInt, _ := new(big.Int).SetString("12345678901234567890", 10)
+	if v.Month != time.March {
+		t.Errorf("assert 'v.Month':: expected '%#v', got '%#v'", time.March, v.Month)
+	}
+	if v.Addr != netip.MustParseAddr("192.0.2.1") {
+		t.Errorf("assert 'v.Addr':: expected '%v', got '%v'", "192.0.2.1", v.Addr)
+	}
+	if v.Int == nil || v.Int.Cmp(bigInt) != 0 {
+		t.Errorf("assert 'v.Int':: expected '%v', got '%v'", bigInt, v.Int)
+	}
+	if v.Url == nil || v.Url.Host != "example.com" {
+		t.Errorf("assert 'v.Url':: expected host '%v', got '%v'", "example.com", v.Url)
+	}
+	if v.Mode != 0644 {
+		t.Errorf("assert 'v.Mode':: expected '%v', got '%v'", os.FileMode(0644), v.Mode)
+	}
+	if string(v.Raw) != `{"name":"luffy"}` {
+		t.Errorf("assert 'v.Raw':: expected '%s', got '%s'", `{"name":"luffy"}`, v.Raw)
+	}
+	if v.Regexp == nil || !v.Regexp.MatchString("aaa") {
+		t.Errorf("assert 'v.Regexp':: expected '%v', got '%v'", "^a+$", v.Regexp)
+	}
+}
+
+func TestBytesBinder_WithRegisteredBytesType(t *testing.T) {
+	type token []byte
+	var v, plain token
+
+	registry := NewConverterRegistry()
+	registry.Register(reflect.TypeOf(token(nil)), func(rv reflect.Value, v interface{}) error {
+		rv.Set(reflect.ValueOf(token(bytes.ToUpper(v.([]byte)))))
+		return nil
+	})
+
+	err := registry.BuildBytesBinder(reflect.ValueOf(&v).Elem()).Bind([]byte("abc"))
+	if err != nil {
+		t.Error(err)
+	}
+	if string(v) != "ABC" {
+		t.Errorf("assert 'v':: expected '%s', got '%s'", "ABC", v)
+	}
+
+	// the types unknown to the converters are still assigned
+	err = BytesBinder(reflect.ValueOf(&plain).Elem()).Bind([]byte("abc"))
+	if err != nil {
+		t.Error(err)
+	}
+	if string(plain) != "abc" {
+		t.Errorf("assert 'plain':: expected '%s', got '%s'", "abc", plain)
+	}
+}
@@ -0,0 +1,30 @@
+package converter
+
+import (
+	"net"
+	"net/netip"
+)
+
+// Addr converts the text form of IP address, or net.IP to netip.Addr.
+func Addr(from interface{}) (netip.Addr, error) {
+	if T, ok := from.(netip.Addr); ok {
+		return T, nil
+	} else if T, ok := from.(*netip.Addr); ok {
+		return *T, nil
+	} else if T, ok := from.(net.IP); ok {
+		return convIPToAddr(T)
+	}
+
+	if s, ok := textOf(from); ok {
+		return netip.ParseAddr(s)
+	}
+	return netip.Addr{}, newConvErr(from, "netip.Addr")
+}
+
+func convIPToAddr(value net.IP) (netip.Addr, error) {
+	addr, ok := netip.AddrFromSlice(value)
+	if !ok {
+		return netip.Addr{}, newConvErr(value, "netip.Addr")
+	}
+	return addr.Unmap(), nil
+}
@@ -0,0 +1,20 @@
+package converter
+
+import (
+	"net/netip"
+)
+
+// AddrPort converts the text form "ip:port" or "[ipv6]:port" to
+// netip.AddrPort.
+func AddrPort(from interface{}) (netip.AddrPort, error) {
+	if T, ok := from.(netip.AddrPort); ok {
+		return T, nil
+	} else if T, ok := from.(*netip.AddrPort); ok {
+		return *T, nil
+	}
+
+	if s, ok := textOf(from); ok {
+		return netip.ParseAddrPort(s)
+	}
+	return netip.AddrPort{}, newConvErr(from, "netip.AddrPort")
+}
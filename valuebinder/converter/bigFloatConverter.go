@@ -0,0 +1,37 @@
+package converter
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+)
+
+// BigFloat converts the text of floating-point number to *big.Float.
+func BigFloat(from interface{}) (*big.Float, error) {
+	if T, ok := from.(*big.Float); ok {
+		return T, nil
+	} else if T, ok := from.(big.Float); ok {
+		return &T, nil
+	}
+
+	if s, ok := textOf(from); ok {
+		return convStringToBigFloat(s)
+	}
+	if n, ok := integerOf(from); ok {
+		return new(big.Float).SetInt64(n), nil
+	}
+	rv := reflect.ValueOf(indirect(from))
+	switch rv.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return big.NewFloat(rv.Float()), nil
+	}
+	return nil, newConvErr(from, "*big.Float")
+}
+
+func convStringToBigFloat(value string) (*big.Float, error) {
+	f, ok := new(big.Float).SetString(value)
+	if !ok {
+		return nil, fmt.Errorf("invalid float '%s'", value)
+	}
+	return f, nil
+}
@@ -0,0 +1,32 @@
+package converter
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// BigInt converts the text of integer to *big.Int, the base prefixes
+// "0x", "0o" and "0b" are accepted.
+func BigInt(from interface{}) (*big.Int, error) {
+	if T, ok := from.(*big.Int); ok {
+		return T, nil
+	} else if T, ok := from.(big.Int); ok {
+		return &T, nil
+	}
+
+	if s, ok := textOf(from); ok {
+		return convStringToBigInt(s)
+	}
+	if n, ok := integerOf(from); ok {
+		return big.NewInt(n), nil
+	}
+	return nil, newConvErr(from, "*big.Int")
+}
+
+func convStringToBigInt(value string) (*big.Int, error) {
+	i, ok := new(big.Int).SetString(value, 0)
+	if !ok {
+		return nil, fmt.Errorf("invalid integer '%s'", value)
+	}
+	return i, nil
+}
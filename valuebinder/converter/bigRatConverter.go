@@ -0,0 +1,42 @@
+package converter
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+)
+
+// BigRat converts the text of fraction "a/b" or floating-point number to
+// *big.Rat.
+func BigRat(from interface{}) (*big.Rat, error) {
+	if T, ok := from.(*big.Rat); ok {
+		return T, nil
+	} else if T, ok := from.(big.Rat); ok {
+		return &T, nil
+	}
+
+	if s, ok := textOf(from); ok {
+		return convStringToBigRat(s)
+	}
+	if n, ok := integerOf(from); ok {
+		return new(big.Rat).SetInt64(n), nil
+	}
+	rv := reflect.ValueOf(indirect(from))
+	switch rv.Kind() {
+	case reflect.Float32, reflect.Float64:
+		r := new(big.Rat).SetFloat64(rv.Float())
+		if r == nil {
+			return nil, fmt.Errorf("invalid rational %v", from)
+		}
+		return r, nil
+	}
+	return nil, newConvErr(from, "*big.Rat")
+}
+
+func convStringToBigRat(value string) (*big.Rat, error) {
+	r, ok := new(big.Rat).SetString(value)
+	if !ok {
+		return nil, fmt.Errorf("invalid rational '%s'", value)
+	}
+	return r, nil
+}
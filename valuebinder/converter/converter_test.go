@@ -0,0 +1,268 @@
+package converter
+
+import (
+	"math/big"
+	"net"
+	"net/mail"
+	"net/netip"
+	"net/url"
+	"os"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+)
+
+type namedString string
+
+func assertConvert[T any](t *testing.T, name string, convert func(interface{}) (T, error), inputs []interface{}, expected T) {
+	t.Helper()
+	for _, input := range inputs {
+		v, err := convert(input)
+		if err != nil {
+			t.Errorf("assert '%s(%#v)':: unexpected error '%v'", name, input, err)
+			continue
+		}
+		if !reflect.DeepEqual(expected, v) {
+			t.Errorf("assert '%s(%#v)':: expected '%v', got '%v'", name, input, expected, v)
+		}
+	}
+}
+
+func assertConvertError[T any](t *testing.T, name string, convert func(interface{}) (T, error), inputs ...interface{}) {
+	t.Helper()
+	for _, input := range inputs {
+		_, err := convert(input)
+		if err == nil {
+			t.Errorf("assert '%s(%#v)':: expected error", name, input)
+		}
+	}
+}
+
+func TestLocation(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Taipei")
+	if err != nil {
+		t.Skip(err)
+	}
+	assertConvert(t, "Location", Location, []interface{}{
+		"Asia/Taipei",
+		[]byte("Asia/Taipei"),
+		namedString("Asia/Taipei"),
+		loc,
+	}, loc)
+	assertConvertError(t, "Location", Location, "Mars/Olympus", 8)
+}
+
+func TestMonth(t *testing.T) {
+	assertConvert(t, "Month", Month, []interface{}{
+		"March",
+		"mar",
+		"3",
+		[]byte("MARCH"),
+		3,
+		uint8(3),
+		time.March,
+	}, time.March)
+	assertConvertError(t, "Month", Month, "Marchy", "13", 0, 1.5)
+}
+
+func TestWeekday(t *testing.T) {
+	assertConvert(t, "Weekday", Weekday, []interface{}{
+		"Sunday",
+		"sun",
+		"0",
+		[]byte("SUNDAY"),
+		0,
+		time.Sunday,
+	}, time.Sunday)
+	assertConvertError(t, "Weekday", Weekday, "someday", "7", -1)
+}
+
+func TestAddr(t *testing.T) {
+	expected := netip.MustParseAddr("192.168.0.1")
+	assertConvert(t, "Addr", Addr, []interface{}{
+		"192.168.0.1",
+		[]byte("192.168.0.1"),
+		net.ParseIP("192.168.0.1"),
+		expected,
+		&expected,
+	}, expected)
+	assertConvertError(t, "Addr", Addr, "192.168.0.256", 1)
+}
+
+func TestAddrPort(t *testing.T) {
+	expected := netip.MustParseAddrPort("[::1]:8080")
+	assertConvert(t, "AddrPort", AddrPort, []interface{}{
+		"[::1]:8080",
+		[]byte("[::1]:8080"),
+		expected,
+	}, expected)
+	assertConvertError(t, "AddrPort", AddrPort, "::1", 8080)
+}
+
+func TestPrefix(t *testing.T) {
+	expected := netip.MustParsePrefix("10.0.0.0/8")
+	assertConvert(t, "Prefix", Prefix, []interface{}{
+		"10.0.0.0/8",
+		[]byte("10.0.0.0/8"),
+		expected,
+	}, expected)
+	assertConvertError(t, "Prefix", Prefix, "10.0.0.0/33", 8)
+}
+
+func TestIPNet(t *testing.T) {
+	_, expected, _ := net.ParseCIDR("10.1.0.0/16")
+	assertConvert(t, "IPNet", IPNet, []interface{}{
+		"10.1.2.3/16",
+		[]byte("10.1.0.0/16"),
+		expected,
+		*expected,
+	}, expected)
+	assertConvertError(t, "IPNet", IPNet, "10.1.2.3", 16)
+}
+
+func TestHardwareAddr(t *testing.T) {
+	expected, _ := net.ParseMAC("00:00:5e:00:53:01")
+	assertConvert(t, "HardwareAddr", HardwareAddr, []interface{}{
+		"00:00:5e:00:53:01",
+		"00-00-5E-00-53-01",
+		[]byte("00:00:5e:00:53:01"),
+		expected,
+	}, expected)
+	assertConvertError(t, "HardwareAddr", HardwareAddr, "00:00:5e", 1)
+}
+
+func TestRegexp(t *testing.T) {
+	expected := regexp.MustCompile(`^[a-z]+\d*$`)
+	for _, input := range []interface{}{`^[a-z]+\d*$`, []byte(`^[a-z]+\d*$`), expected} {
+		v, err := Regexp(input)
+		if err != nil {
+			t.Errorf("assert 'Regexp(%#v)':: unexpected error '%v'", input, err)
+			continue
+		}
+		if v.String() != expected.String() {
+			t.Errorf("assert 'Regexp(%#v)':: expected '%v', got '%v'", input, expected, v)
+		}
+	}
+	assertConvertError(t, "Regexp", Regexp, "[a-z", 1)
+}
+
+func TestBigInt(t *testing.T) {
+	expected, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	assertConvert(t, "BigInt", BigInt, []interface{}{
+		"123456789012345678901234567890",
+		[]byte("123456789012345678901234567890"),
+		"0x18ee90ff6c373e0ee4e3f0ad2",
+		expected,
+		*expected,
+	}, expected)
+	assertConvert(t, "BigInt", BigInt, []interface{}{42, int64(42), uint(42)}, big.NewInt(42))
+	assertConvertError(t, "BigInt", BigInt, "12.5", 1.5)
+}
+
+func TestBigFloat(t *testing.T) {
+	for _, input := range []interface{}{"1.5", []byte("1.5"), 1.5, big.NewFloat(1.5)} {
+		v, err := BigFloat(input)
+		if err != nil {
+			t.Errorf("assert 'BigFloat(%#v)':: unexpected error '%v'", input, err)
+			continue
+		}
+		if v.Cmp(big.NewFloat(1.5)) != 0 {
+			t.Errorf("assert 'BigFloat(%#v)':: expected '%v', got '%v'", input, 1.5, v)
+		}
+	}
+	v, err := BigFloat(3)
+	if err != nil || v.Cmp(big.NewFloat(3)) != 0 {
+		t.Errorf("assert 'BigFloat(3)':: expected '%v', got '%v' (%v)", 3, v, err)
+	}
+	assertConvertError(t, "BigFloat", BigFloat, "1.5.5", true)
+}
+
+func TestBigRat(t *testing.T) {
+	assertConvert(t, "BigRat", BigRat, []interface{}{
+		"1/4",
+		"0.25",
+		[]byte("1/4"),
+		0.25,
+		big.NewRat(1, 4),
+	}, big.NewRat(1, 4))
+	assertConvert(t, "BigRat", BigRat, []interface{}{2}, big.NewRat(2, 1))
+	assertConvertError(t, "BigRat", BigRat, "1/0", "one", true)
+}
+
+func TestMailAddress(t *testing.T) {
+	expected := mail.Address{Name: "Luffy", Address: "luffy@example.com"}
+	assertConvert(t, "MailAddress", MailAddress, []interface{}{
+		"Luffy <luffy@example.com>",
+		[]byte("Luffy <luffy@example.com>"),
+		expected,
+		&expected,
+	}, expected)
+	assertConvertError(t, "MailAddress", MailAddress, "luffy", 1)
+}
+
+func TestFileMode(t *testing.T) {
+	assertConvert(t, "FileMode", FileMode, []interface{}{
+		"0644",
+		"644",
+		"0o644",
+		[]byte("0644"),
+		0644,
+		os.FileMode(0644),
+	}, os.FileMode(0644))
+	assertConvertError(t, "FileMode", FileMode, "0958", "rw-r--r--", 1.5)
+}
+
+func TestUrlPtr(t *testing.T) {
+	expected, _ := url.Parse("https://example.com/path?q=1")
+	assertConvert(t, "UrlPtr", UrlPtr, []interface{}{
+		"https://example.com/path?q=1",
+		[]byte("https://example.com/path?q=1"),
+		expected,
+		*expected,
+	}, expected)
+	assertConvertError(t, "UrlPtr", UrlPtr, "://bad", 1)
+}
+
+func TestTimeLayout(t *testing.T) {
+	expected := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	assertConvert(t, "TimeLayout", func(from interface{}) (time.Time, error) {
+		return TimeLayout(from, "2006/01/02", time.UTC)
+	}, []interface{}{
+		"2024/01/02",
+		[]byte("2024/01/02"),
+		namedString("2024/01/02"),
+		expected,
+	}, expected)
+	assertConvertError(t, "TimeLayout", func(from interface{}) (time.Time, error) {
+		return TimeLayout(from, "2006/01/02", time.UTC)
+	}, "2024-01-02", 20240102)
+
+	// the time.Time is converted to loc
+	v, err := TimeLayout(expected, "2006/01/02", time.FixedZone("UTC+8", 8*60*60))
+	if err != nil || !v.Equal(expected) || v.Location().String() != "UTC+8" {
+		t.Errorf("assert 'TimeLayout(time.Time)':: expected '%v' in '%v', got '%v' (%v)", expected, "UTC+8", v, err)
+	}
+	v, err = TimeLayout("2024/01/02", "2006/01/02", nil)
+	if err != nil || v != expected {
+		t.Errorf("assert 'TimeLayout(nil location)':: expected '%v', got '%v' (%v)", expected, v, err)
+	}
+}
+
+func TestUnixTime(t *testing.T) {
+	expected := time.Date(2024, 1, 2, 0, 0, 0, 250000000, time.UTC)
+	assertConvert(t, "UnixTime", func(from interface{}) (time.Time, error) {
+		return UnixTime(from, time.Millisecond)
+	}, []interface{}{
+		"1704153600250",
+		[]byte("1704153600250"),
+		"1704153600250.0",
+		int64(1704153600250),
+		uint64(1704153600250),
+		1704153600250.0,
+		expected,
+	}, expected)
+	assertConvertError(t, "UnixTime", func(from interface{}) (time.Time, error) {
+		return UnixTime(from, time.Millisecond)
+	}, "yesterday", "NaN", true)
+}
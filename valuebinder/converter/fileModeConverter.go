@@ -0,0 +1,35 @@
+package converter
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// FileMode converts the octal permission text, e.g. "0644", "644" or
+// "0o755", or the integer to os.FileMode.
+func FileMode(from interface{}) (os.FileMode, error) {
+	if T, ok := from.(os.FileMode); ok {
+		return T, nil
+	} else if T, ok := from.(*os.FileMode); ok {
+		return *T, nil
+	}
+
+	if s, ok := textOf(from); ok {
+		return convStringToFileMode(s)
+	}
+	if n, ok := integerOf(from); ok {
+		return os.FileMode(n), nil
+	}
+	return 0, newConvErr(from, "os.FileMode")
+}
+
+func convStringToFileMode(value string) (os.FileMode, error) {
+	v := strings.TrimPrefix(strings.TrimPrefix(value, "0o"), "0O")
+	mode, err := strconv.ParseUint(v, 8, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid file mode '%s'", value)
+	}
+	return os.FileMode(mode), nil
+}
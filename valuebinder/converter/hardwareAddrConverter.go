@@ -0,0 +1,20 @@
+package converter
+
+import (
+	"net"
+)
+
+// HardwareAddr converts the text form of MAC address, e.g.
+// "00:00:5e:00:53:01", to net.HardwareAddr.
+func HardwareAddr(from interface{}) (net.HardwareAddr, error) {
+	if T, ok := from.(net.HardwareAddr); ok {
+		return T, nil
+	} else if T, ok := from.(*net.HardwareAddr); ok {
+		return *T, nil
+	}
+
+	if s, ok := textOf(from); ok {
+		return net.ParseMAC(s)
+	}
+	return nil, newConvErr(from, "net.HardwareAddr")
+}
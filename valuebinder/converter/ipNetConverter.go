@@ -0,0 +1,25 @@
+package converter
+
+import (
+	"net"
+)
+
+// IPNet converts the CIDR notation, e.g. "10.0.0.0/8", to *net.IPNet. The
+// IP of the result is masked as net.ParseCIDR does.
+func IPNet(from interface{}) (*net.IPNet, error) {
+	if T, ok := from.(*net.IPNet); ok {
+		return T, nil
+	} else if T, ok := from.(net.IPNet); ok {
+		return &T, nil
+	}
+
+	if s, ok := textOf(from); ok {
+		return convStringToIPNet(s)
+	}
+	return nil, newConvErr(from, "*net.IPNet")
+}
+
+func convStringToIPNet(value string) (*net.IPNet, error) {
+	_, ipnet, err := net.ParseCIDR(value)
+	return ipnet, err
+}
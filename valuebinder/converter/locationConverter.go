@@ -0,0 +1,24 @@
+package converter
+
+import (
+	"time"
+)
+
+// Location converts the IANA time zone name, e.g. "Asia/Taipei", "UTC" or
+// "Local", to *time.Location.
+func Location(from interface{}) (*time.Location, error) {
+	if T, ok := from.(*time.Location); ok {
+		return T, nil
+	} else if T, ok := from.(time.Location); ok {
+		return &T, nil
+	}
+
+	if s, ok := textOf(from); ok {
+		return convStringToLocation(s)
+	}
+	return nil, newConvErr(from, "*time.Location")
+}
+
+func convStringToLocation(value string) (*time.Location, error) {
+	return time.LoadLocation(value)
+}
@@ -0,0 +1,28 @@
+package converter
+
+import (
+	"net/mail"
+)
+
+// MailAddress converts the RFC 5322 address, e.g. "Luffy <luffy@example.com>",
+// to mail.Address.
+func MailAddress(from interface{}) (mail.Address, error) {
+	if T, ok := from.(mail.Address); ok {
+		return T, nil
+	} else if T, ok := from.(*mail.Address); ok {
+		return *T, nil
+	}
+
+	if s, ok := textOf(from); ok {
+		return convStringToMailAddress(s)
+	}
+	return mail.Address{}, newConvErr(from, "mail.Address")
+}
+
+func convStringToMailAddress(value string) (mail.Address, error) {
+	addr, err := mail.ParseAddress(value)
+	if err != nil {
+		return mail.Address{}, err
+	}
+	return *addr, nil
+}
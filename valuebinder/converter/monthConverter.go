@@ -0,0 +1,47 @@
+package converter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Month converts the English name of month, its three-letter abbreviation
+// or the number 1 to 12 to time.Month.
+func Month(from interface{}) (time.Month, error) {
+	if T, ok := from.(time.Month); ok {
+		return T, nil
+	} else if T, ok := from.(*time.Month); ok {
+		return *T, nil
+	}
+
+	if s, ok := textOf(from); ok {
+		return convStringToMonth(s)
+	}
+	if n, ok := integerOf(from); ok {
+		return convIntegerToMonth(n)
+	}
+	return 0, newConvErr(from, "time.Month")
+}
+
+func convStringToMonth(value string) (time.Month, error) {
+	value = strings.TrimSpace(value)
+	if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return convIntegerToMonth(n)
+	}
+	for m := time.January; m <= time.December; m++ {
+		name := m.String()
+		if strings.EqualFold(value, name) || strings.EqualFold(value, name[:3]) {
+			return m, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid month '%s'", value)
+}
+
+func convIntegerToMonth(value int64) (time.Month, error) {
+	if value < int64(time.January) || value > int64(time.December) {
+		return 0, fmt.Errorf("month %d out of range", value)
+	}
+	return time.Month(value), nil
+}
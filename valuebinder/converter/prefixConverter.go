@@ -0,0 +1,19 @@
+package converter
+
+import (
+	"net/netip"
+)
+
+// Prefix converts the CIDR notation, e.g. "10.0.0.0/8", to netip.Prefix.
+func Prefix(from interface{}) (netip.Prefix, error) {
+	if T, ok := from.(netip.Prefix); ok {
+		return T, nil
+	} else if T, ok := from.(*netip.Prefix); ok {
+		return *T, nil
+	}
+
+	if s, ok := textOf(from); ok {
+		return netip.ParsePrefix(s)
+	}
+	return netip.Prefix{}, newConvErr(from, "netip.Prefix")
+}
@@ -0,0 +1,17 @@
+package converter
+
+import (
+	"regexp"
+)
+
+// Regexp compiles the text to *regexp.Regexp.
+func Regexp(from interface{}) (*regexp.Regexp, error) {
+	if T, ok := from.(*regexp.Regexp); ok {
+		return T, nil
+	}
+
+	if s, ok := textOf(from); ok {
+		return regexp.Compile(s)
+	}
+	return nil, newConvErr(from, "*regexp.Regexp")
+}
@@ -0,0 +1,68 @@
+package converter
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TimeLayout converts from into time.Time, the text is parsed by layout in
+// loc, or UTC if loc is nil, if it carries no zone; the time.Time is
+// converted to loc if loc is not nil.
+func TimeLayout(from interface{}, layout string, loc *time.Location) (time.Time, error) {
+	if T, ok := indirect(from).(time.Time); ok {
+		if loc != nil {
+			T = T.In(loc)
+		}
+		return T, nil
+	}
+	if text, ok := textOf(from); ok {
+		if loc == nil {
+			loc = time.UTC
+		}
+		return time.ParseInLocation(layout, strings.TrimSpace(text), loc)
+	}
+	return time.Time{}, newConvErr(from, "time.Time")
+}
+
+// UnixTime converts from, a number or the text of it, into time.Time in
+// UTC as the time since the epoch in unit. The unit must divide a second.
+func UnixTime(from interface{}, unit time.Duration) (time.Time, error) {
+	if T, ok := indirect(from).(time.Time); ok {
+		return T, nil
+	}
+	if text, ok := textOf(from); ok {
+		text = strings.TrimSpace(text)
+		if n, err := strconv.ParseInt(text, 10, 64); err == nil {
+			return unixInt(n, unit), nil
+		}
+		if f, err := strconv.ParseFloat(text, 64); err == nil && isFinite(f) {
+			return unixFloat(f, unit), nil
+		}
+		return time.Time{}, newConvErr(from, "time.Time")
+	}
+	if n, ok := integerOf(from); ok {
+		return unixInt(n, unit), nil
+	}
+	if f, ok := floatOf(from); ok && isFinite(f) {
+		return unixFloat(f, unit), nil
+	}
+	return time.Time{}, newConvErr(from, "time.Time")
+}
+
+func unixInt(n int64, unit time.Duration) time.Time {
+	per := int64(time.Second / unit)
+	// time.Unix normalizes the negative nanoseconds
+	return time.Unix(n/per, n%per*int64(unit)).UTC()
+}
+
+func unixFloat(f float64, unit time.Duration) time.Time {
+	// split the whole units off to keep the precision of the fraction
+	whole, frac := math.Modf(f)
+	return unixInt(int64(whole), unit).Add(time.Duration(math.Round(frac * float64(unit))))
+}
+
+func isFinite(f float64) bool {
+	return !math.IsNaN(f) && !math.IsInf(f, 0)
+}
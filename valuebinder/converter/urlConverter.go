@@ -14,6 +14,8 @@ func Url(from interface{}) (url.URL, error) {
 		return T, nil
 	} else if T, ok := from.(*url.URL); ok {
 		return *T, nil
+	} else if T, ok := from.([]byte); ok {
+		return convStringToUrl(string(T))
 	} else if T, ok := from.(string); ok {
 		return convStringToUrl(T)
 	}
@@ -33,7 +35,23 @@ func Url(from interface{}) (url.URL, error) {
 	return url.URL{}, newConvErr(from, "url.URL")
 }
 
+// UrlPtr converts the text of URL to *url.URL.
+func UrlPtr(from interface{}) (*url.URL, error) {
+	if T, ok := from.(*url.URL); ok {
+		return T, nil
+	}
+
+	u, err := Url(from)
+	if err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
 func convStringToUrl(value string) (url.URL, error) {
 	T, err := url.Parse(value)
-	return *T, err
+	if err != nil {
+		return url.URL{}, err
+	}
+	return *T, nil
 }
@@ -1,6 +1,7 @@
 package converter
 
 import (
+	"reflect"
 	_ "unsafe"
 
 	_ "github.com/cstockton/go-conv"
@@ -11,3 +12,47 @@ func newConvErr(from interface{}, to string) error
 
 //go:linkname indirect github.com/cstockton/go-conv/internal/refutil.Indirect
 func indirect(value interface{}) interface{}
+
+// textOf returns the text of from if it is a string, a []byte or a named
+// type of them.
+func textOf(from interface{}) (string, bool) {
+	switch T := from.(type) {
+	case string:
+		return T, true
+	case []byte:
+		return string(T), true
+	}
+
+	rv := reflect.ValueOf(indirect(from))
+	switch rv.Kind() {
+	case reflect.String:
+		return rv.String(), true
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return string(rv.Bytes()), true
+		}
+	}
+	return "", false
+}
+
+// integerOf returns the value of from if it is an integer.
+func integerOf(from interface{}) (int64, bool) {
+	rv := reflect.ValueOf(indirect(from))
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(rv.Uint()), true
+	}
+	return 0, false
+}
+
+// floatOf returns the value of from if it is a floating-point number.
+func floatOf(from interface{}) (float64, bool) {
+	rv := reflect.ValueOf(indirect(from))
+	switch rv.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+	return 0, false
+}
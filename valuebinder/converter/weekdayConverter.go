@@ -0,0 +1,47 @@
+package converter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Weekday converts the English name of weekday, its three-letter
+// abbreviation or the number 0 (Sunday) to 6 to time.Weekday.
+func Weekday(from interface{}) (time.Weekday, error) {
+	if T, ok := from.(time.Weekday); ok {
+		return T, nil
+	} else if T, ok := from.(*time.Weekday); ok {
+		return *T, nil
+	}
+
+	if s, ok := textOf(from); ok {
+		return convStringToWeekday(s)
+	}
+	if n, ok := integerOf(from); ok {
+		return convIntegerToWeekday(n)
+	}
+	return 0, newConvErr(from, "time.Weekday")
+}
+
+func convStringToWeekday(value string) (time.Weekday, error) {
+	value = strings.TrimSpace(value)
+	if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return convIntegerToWeekday(n)
+	}
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		name := d.String()
+		if strings.EqualFold(value, name) || strings.EqualFold(value, name[:3]) {
+			return d, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid weekday '%s'", value)
+}
+
+func convIntegerToWeekday(value int64) (time.Weekday, error) {
+	if value < int64(time.Sunday) || value > int64(time.Saturday) {
+		return 0, fmt.Errorf("weekday %d out of range", value)
+	}
+	return time.Weekday(value), nil
+}
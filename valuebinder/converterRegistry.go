@@ -0,0 +1,142 @@
+package valuebinder
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+
+	"github.com/Bofry/structproto/common"
+)
+
+var (
+	// DefaultConverters is the global ConverterRegistry consulted by
+	// StringBinder, ScalarBinder and BytesBinder.
+	DefaultConverters = &ConverterRegistry{}
+)
+
+// Converter binds v into rv, the rv is settable and never a pointer.
+type Converter func(rv reflect.Value, v interface{}) error
+
+// ConverterRegistry keeps the Converters by the type they bind into. It is
+// safe for concurrent use, the lookups take no lock.
+type ConverterRegistry struct {
+	mutex      sync.Mutex
+	converters atomic.Pointer[map[reflect.Type]Converter]
+	version    atomic.Uint64
+	parent     *ConverterRegistry
+}
+
+// NewConverterRegistry returns a ConverterRegistry overrides the
+// DefaultConverters, the types it does not register are looked up in
+// DefaultConverters.
+func NewConverterRegistry() *ConverterRegistry {
+	return &ConverterRegistry{
+		parent: DefaultConverters,
+	}
+}
+
+// RegisterConverter registers the converter of type t to DefaultConverters.
+func RegisterConverter(t reflect.Type, converter Converter) {
+	DefaultConverters.Register(t, converter)
+}
+
+// IsKnownType reports t is bound as a single value by the converters in
+// DefaultConverters or the built-in converters.
+func IsKnownType(t reflect.Type) bool {
+	return DefaultConverters.IsKnownType(t)
+}
+
+// Register registers the converter of type t, the previous one of t is
+// replaced.
+func (r *ConverterRegistry) Register(t reflect.Type, converter Converter) {
+	if t == nil {
+		panic("specified argument 't' cannot be nil")
+	}
+	if converter == nil {
+		panic("specified argument 'converter' cannot be nil")
+	}
+
+	r.update(func(converters map[reflect.Type]Converter) {
+		converters[t] = converter
+	})
+}
+
+// Unregister removes the converter of type t.
+func (r *ConverterRegistry) Unregister(t reflect.Type) {
+	r.update(func(converters map[reflect.Type]Converter) {
+		delete(converters, t)
+	})
+}
+
+// Lookup returns the converter of type t registered in r or its parent.
+func (r *ConverterRegistry) Lookup(t reflect.Type) (Converter, bool) {
+	for registry := r; registry != nil; registry = registry.parent {
+		if converters := registry.converters.Load(); converters != nil {
+			if converter, ok := (*converters)[t]; ok {
+				return converter, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// Version returns a number which changes whenever a converter is
+// registered or unregistered in r or its parent.
+func (r *ConverterRegistry) Version() uint64 {
+	var version uint64
+	for registry := r; registry != nil; registry = registry.parent {
+		version += registry.version.Load()
+	}
+	return version
+}
+
+// IsKnownType reports t is bound as a single value by the converters in r
+// or its parent, or by the built-in converters.
+func (r *ConverterRegistry) IsKnownType(t reflect.Type) bool {
+	if _, ok := r.Lookup(t); ok {
+		return true
+	}
+	if _, ok := knownTypeBinderTable[t]; ok {
+		return true
+	}
+	if _, ok := knownAttrTypeBinderTable[t]; ok {
+		return true
+	}
+	_, ok := knownPointerTypeBinderTable[t]
+	return ok
+}
+
+// BuildStringBinder is the ValueBindProvider of StringBinder which
+// consults r.
+func (r *ConverterRegistry) BuildStringBinder(rv reflect.Value) common.ValueBinder {
+	return &contextBinder{rv, bindContext{converters: r}, bindStringInput}
+}
+
+// BuildScalarBinder is the ValueBindProvider of ScalarBinder which
+// consults r.
+func (r *ConverterRegistry) BuildScalarBinder(rv reflect.Value) common.ValueBinder {
+	return &contextBinder{rv, bindContext{converters: r}, bindScalarInput}
+}
+
+// BuildBytesBinder is the ValueBindProvider of BytesBinder which consults
+// r.
+func (r *ConverterRegistry) BuildBytesBinder(rv reflect.Value) common.ValueBinder {
+	return &contextBinder{rv, bindContext{converters: r}, bindBytesInput}
+}
+
+// update applies fn on a copy of the converters, so the lookups in
+// progress still see the previous ones.
+func (r *ConverterRegistry) update(fn func(converters map[reflect.Type]Converter)) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var converters = make(map[reflect.Type]Converter)
+	if prev := r.converters.Load(); prev != nil {
+		for k, v := range *prev {
+			converters[k] = v
+		}
+	}
+	fn(converters)
+	r.converters.Store(&converters)
+	r.version.Add(1)
+}
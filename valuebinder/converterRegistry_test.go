@@ -0,0 +1,199 @@
+package valuebinder
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type money struct {
+	cents int64
+}
+
+var typeOfMoney = reflect.TypeOf(money{})
+
+func bindMoney(rv reflect.Value, v interface{}) error {
+	var s string
+	switch v := v.(type) {
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	case int:
+		rv.Set(reflect.ValueOf(money{int64(v) * 100}))
+		return nil
+	default:
+		return fmt.Errorf("cannot convert %T to money", v)
+	}
+
+	units, cents, _ := strings.Cut(strings.TrimPrefix(s, "$"), ".")
+	u, err := strconv.ParseInt(units, 10, 64)
+	if err != nil {
+		return err
+	}
+	var c int64
+	if len(cents) > 0 {
+		c, err = strconv.ParseInt(cents, 10, 64)
+		if err != nil {
+			return err
+		}
+	}
+	rv.Set(reflect.ValueOf(money{u*100 + c}))
+	return nil
+}
+
+func TestConverterRegistry_Global(t *testing.T) {
+	RegisterConverter(typeOfMoney, bindMoney)
+	defer DefaultConverters.Unregister(typeOfMoney)
+
+	{
+		var target money
+		err := StringBinder(reflect.ValueOf(&target).Elem()).Bind("$12.34")
+		if err != nil {
+			t.Error(err)
+		}
+		if target.cents != 1234 {
+			t.Errorf("assert 'target':: expected '%#v', got '%#v'", 1234, target.cents)
+		}
+	}
+	{
+		var target []*money
+		err := StringBinder(reflect.ValueOf(&target).Elem()).Bind("$1,$2.50")
+		if err != nil {
+			t.Error(err)
+		}
+		if len(target) != 2 || target[0].cents != 100 || target[1].cents != 250 {
+			t.Errorf("assert 'target':: expected '%#v', got '%#v'", []int64{100, 250}, target)
+		}
+	}
+	{
+		var target money
+		err := BytesBinder(reflect.ValueOf(&target).Elem()).Bind([]byte("$3"))
+		if err != nil {
+			t.Error(err)
+		}
+		if target.cents != 300 {
+			t.Errorf("assert 'target':: expected '%#v', got '%#v'", 300, target.cents)
+		}
+	}
+	{
+		var target map[money]money
+		err := ScalarBinder(reflect.ValueOf(&target).Elem()).Bind(map[string]int{"$1": 2})
+		if err != nil {
+			t.Error(err)
+		}
+		expected := map[money]money{{100}: {200}}
+		if !reflect.DeepEqual(expected, target) {
+			t.Errorf("assert 'target':: expected '%#v', got '%#v'", expected, target)
+		}
+	}
+}
+
+func TestConverterRegistry_Override(t *testing.T) {
+	var target money
+	rv := reflect.ValueOf(&target).Elem()
+
+	// no converter of money by default
+	err := StringBinder(rv).Bind("$1")
+	if err == nil {
+		t.Errorf("assert 'StringBinder.Bind()':: expected error")
+	}
+
+	registry := NewConverterRegistry()
+	registry.Register(typeOfMoney, bindMoney)
+
+	err = registry.BuildStringBinder(rv).Bind("$1")
+	if err != nil {
+		t.Error(err)
+	}
+	if target.cents != 100 {
+		t.Errorf("assert 'target':: expected '%#v', got '%#v'", 100, target.cents)
+	}
+
+	// the registry consults DefaultConverters on the types it has not
+	RegisterConverter(typeOfMoney, func(rv reflect.Value, v interface{}) error {
+		return fmt.Errorf("global")
+	})
+	defer DefaultConverters.Unregister(typeOfMoney)
+
+	err = registry.BuildScalarBinder(rv).Bind("$2")
+	if err != nil {
+		t.Error(err)
+	}
+	registry.Unregister(typeOfMoney)
+	err = registry.BuildBytesBinder(rv).Bind([]byte("$3"))
+	if err == nil || err.Error() != "global" {
+		t.Errorf("assert 'BytesBinder.Bind()':: expected error '%v', got '%v'", "global", err)
+	}
+}
+
+func TestConverterRegistry_Concurrency(t *testing.T) {
+	var (
+		registry = NewConverterRegistry()
+		wg       sync.WaitGroup
+	)
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			registry.Register(typeOfMoney, bindMoney)
+		}()
+		go func() {
+			defer wg.Done()
+			var target money
+			_ = registry.BuildStringBinder(reflect.ValueOf(&target).Elem()).Bind("$1")
+		}()
+	}
+	wg.Wait()
+
+	if _, ok := registry.Lookup(typeOfMoney); !ok {
+		t.Errorf("assert 'ConverterRegistry.Lookup()':: expected converter of %s", typeOfMoney)
+	}
+}
+
+func TestConverterRegistry_IsKnownType(t *testing.T) {
+	registry := NewConverterRegistry()
+	registry.Register(typeOfMoney, bindMoney)
+
+	var cases = []struct {
+		typ      reflect.Type
+		expected bool
+	}{
+		{typeOfMoney, true},
+		{typeOfTime, true},
+		{typeOfMailAddress, true},
+		{typeOfIPNetPtr, true},
+		{typeOfIPNetPtr.Elem(), false},
+		{reflect.TypeOf(struct{ Name string }{}), false},
+	}
+	for _, c := range cases {
+		if v := registry.IsKnownType(c.typ); v != c.expected {
+			t.Errorf("assert 'IsKnownType(%v)':: expected '%v', got '%v'", c.typ, c.expected, v)
+		}
+	}
+	if IsKnownType(typeOfMoney) {
+		t.Errorf("assert 'IsKnownType(%v)':: expected '%v', got '%v'", typeOfMoney, false, true)
+	}
+}
+
+func TestConverterRegistry_Version(t *testing.T) {
+	parent := &ConverterRegistry{}
+	registry := &ConverterRegistry{parent: parent}
+
+	versions := map[uint64]bool{registry.Version(): true}
+	for _, update := range []func(){
+		func() { registry.Register(typeOfMoney, bindMoney) },
+		func() { parent.Register(typeOfMoney, bindMoney) },
+		func() { registry.Unregister(typeOfMoney) },
+	} {
+		update()
+		v := registry.Version()
+		if versions[v] {
+			t.Errorf("assert 'ConverterRegistry.Version()':: expected a new version, got '%v'", v)
+		}
+		versions[v] = true
+	}
+}
@@ -2,10 +2,16 @@ package valuebinder
 
 import (
 	"bytes"
+	"encoding"
 	"encoding/json"
+	"math/big"
 	"net"
+	"net/mail"
+	"net/netip"
 	"net/url"
+	"os"
 	"reflect"
+	"regexp"
 	"time"
 
 	"github.com/Bofry/structproto/common"
@@ -20,18 +26,33 @@ var (
 	typeOfString = reflect.TypeOf("")
 	typeOfBytes  = reflect.TypeOf([]byte(nil))
 
-	typeOfUnmarshaler = reflect.TypeOf((*common.Unmarshaler)(nil)).Elem()
-	typeOfDuration    = reflect.TypeOf(time.Nanosecond)
-	typeOfUrl         = reflect.TypeOf(url.URL{})
-	typeOfTime        = reflect.TypeOf(time.Time{})
-	typeOfRawContent  = reflect.TypeOf(types.RawContent(nil))
-	typeOfRawMessage  = reflect.TypeOf(json.RawMessage(nil))
-	typeOfIP          = reflect.TypeOf(net.IP(nil))
-	typeOfBuffer      = reflect.TypeOf(bytes.Buffer{})
-)
-
-type (
-	typeBinder func(rv reflect.Value, v interface{}) error
+	typeOfUnmarshaler       = reflect.TypeOf((*common.Unmarshaler)(nil)).Elem()
+	typeOfTextUnmarshaler   = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	typeOfBinaryUnmarshaler = reflect.TypeOf((*encoding.BinaryUnmarshaler)(nil)).Elem()
+	typeOfJsonUnmarshaler   = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	typeOfDuration          = reflect.TypeOf(time.Nanosecond)
+	typeOfUrl               = reflect.TypeOf(url.URL{})
+	typeOfTime              = reflect.TypeOf(time.Time{})
+	typeOfRawContent        = reflect.TypeOf(types.RawContent(nil))
+	typeOfRawMessage        = reflect.TypeOf(json.RawMessage(nil))
+	typeOfIP                = reflect.TypeOf(net.IP(nil))
+	typeOfBuffer            = reflect.TypeOf(bytes.Buffer{})
+	typeOfMonth             = reflect.TypeOf(time.Month(0))
+	typeOfWeekday           = reflect.TypeOf(time.Weekday(0))
+	typeOfAddr              = reflect.TypeOf(netip.Addr{})
+	typeOfAddrPort          = reflect.TypeOf(netip.AddrPort{})
+	typeOfPrefix            = reflect.TypeOf(netip.Prefix{})
+	typeOfHardwareAddr      = reflect.TypeOf(net.HardwareAddr(nil))
+	typeOfMailAddress       = reflect.TypeOf(mail.Address{})
+	typeOfFileMode          = reflect.TypeOf(os.FileMode(0))
+
+	typeOfLocationPtr = reflect.TypeOf((*time.Location)(nil))
+	typeOfIPNetPtr    = reflect.TypeOf((*net.IPNet)(nil))
+	typeOfRegexpPtr   = reflect.TypeOf((*regexp.Regexp)(nil))
+	typeOfBigIntPtr   = reflect.TypeOf((*big.Int)(nil))
+	typeOfBigFloatPtr = reflect.TypeOf((*big.Float)(nil))
+	typeOfBigRatPtr   = reflect.TypeOf((*big.Rat)(nil))
+	typeOfUrlPtr      = reflect.TypeOf((*url.URL)(nil))
 )
 
 var _ common.ValueBindProvider = BuildIgnoreBinder
@@ -9,7 +9,7 @@ import (
 
 var (
 	_ common.ValueBindProvider = BuildScalarBinder
-	_ common.ValueBinder       = new(ScalarBinder)
+	_ common.AttrBinder        = new(ScalarBinder)
 )
 
 type ScalarBinder reflect.Value
@@ -19,22 +19,40 @@ func BuildScalarBinder(rv reflect.Value) common.ValueBinder {
 }
 
 func (binder ScalarBinder) Bind(v interface{}) error {
-	rf := reflect.Value(binder)
-	{
+	return bindScalarInput(reflect.Value(binder), v, bindContext{converters: DefaultConverters})
+}
+
+// WithAttrs implements common.AttrBinder.
+func (binder ScalarBinder) WithAttrs(attrs map[string]string) common.ValueBinder {
+	return &contextBinder{
+		rv:   reflect.Value(binder),
+		ctx:  bindContext{converters: DefaultConverters, attrs: attrs},
+		bind: bindScalarInput,
+	}
+}
+
+func bindScalarInput(rf reflect.Value, v interface{}, ctx bindContext) error {
+	// the types specialized by the attributes are converted even if
+	// assignable, e.g. tz on time.Time
+	if _, ok := knownAttrTypeBinderTable[rf.Type()]; !ok || len(ctx.attrs) == 0 {
 		rv := reflect.ValueOf(v)
-		if rv.Type().AssignableTo(rf.Type()) {
+		if ctx.assignable(rv.Type(), rf.Type()) {
 			rf.Set(rv)
 			return nil
 		}
 	}
-	return binder.bindValueImpl(rf, v)
+	return bindScalar(rf, v, ctx)
 }
 
-func (binder ScalarBinder) bindValueImpl(rv reflect.Value, v interface{}) error {
+func bindScalar(rv reflect.Value, v interface{}, ctx bindContext) error {
+	if ok, err := bindKnownPointerType(rv, v, ctx); ok {
+		return err
+	}
+
 	rv = indirectVal(reflecting.AssignZero(rv))
 	var err error
 
-	if ok, err := bindKnownType(rv, v); ok {
+	if ok, err := bindKnownType(rv, v, ctx); ok {
 		return err
 	}
 
@@ -46,7 +64,7 @@ func (binder ScalarBinder) bindValueImpl(rv reflect.Value, v interface{}) error
 			size := in.Len()
 			container := reflect.MakeSlice(rv.Type(), size, size)
 			for i := 0; i < size; i++ {
-				err := binder.bindValueImpl(container.Index(i), in.Index(i).Interface())
+				err := bindScalar(container.Index(i), in.Index(i).Interface(), ctx)
 				if err != nil {
 					return &SliceBindingError{
 						Value: v,
@@ -74,11 +92,11 @@ func (binder ScalarBinder) bindValueImpl(rv reflect.Value, v interface{}) error
 					outKey := reflect.New(out.Type().Key())
 					outVal := reflect.New(out.Type().Elem())
 
-					err = binder.bindValueImpl(outKey, key.Interface())
+					err = bindScalar(outKey, key.Interface(), ctx)
 					if err != nil {
 						return err
 					}
-					err = binder.bindValueImpl(outVal, val.Interface())
+					err = bindScalar(outVal, val.Interface(), ctx)
 					if err != nil {
 						return err
 					}
@@ -303,3 +303,20 @@ func TestScalarBinder_WithMap_ValueIntToPtrInt(t *testing.T) {
 		t.Errorf("assert 'target':: expected '%#v', got '%#v'", expected, target)
 	}
 }
+
+func TestScalarBinder_WithHardwareAddr(t *testing.T) {
+	var target net.HardwareAddr
+	var input = []byte("00:00:5e:00:53:01")
+
+	rv := reflect.ValueOf(&target).Elem()
+	binder := ScalarBinder(rv)
+	err := binder.Bind(input)
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected := net.HardwareAddr{0x00, 0x00, 0x5e, 0x00, 0x53, 0x01}
+	if !reflect.DeepEqual(expected, target) {
+		t.Errorf("assert 'target':: expected '%#v', got '%#v'", expected, target)
+	}
+}
@@ -3,7 +3,6 @@ package valuebinder
 import (
 	"fmt"
 	"reflect"
-	"strings"
 
 	"github.com/Bofry/structproto/common"
 	"github.com/Bofry/structproto/reflecting"
@@ -11,7 +10,7 @@ import (
 
 var (
 	_ common.ValueBindProvider = BuildStringBinder
-	_ common.ValueBinder       = new(StringBinder)
+	_ common.AttrBinder        = new(StringBinder)
 )
 
 type StringBinder reflect.Value
@@ -21,24 +20,40 @@ func BuildStringBinder(rv reflect.Value) common.ValueBinder {
 }
 
 func (binder StringBinder) Bind(input interface{}) error {
+	return bindStringInput(reflect.Value(binder), input, bindContext{converters: DefaultConverters})
+}
+
+// WithAttrs implements common.AttrBinder.
+func (binder StringBinder) WithAttrs(attrs map[string]string) common.ValueBinder {
+	return &contextBinder{
+		rv:   reflect.Value(binder),
+		ctx:  bindContext{converters: DefaultConverters, attrs: attrs},
+		bind: bindStringInput,
+	}
+}
+
+func bindStringInput(rv reflect.Value, input interface{}, ctx bindContext) error {
 	v, ok := input.(string)
 	if !ok {
 		return fmt.Errorf("cannot bind type %T from input", input)
 	}
-	rv := reflect.Value(binder)
 
 	if typeOfString.AssignableTo(rv.Type()) {
 		rv.Set(reflect.ValueOf(v))
 		return nil
 	}
-	return binder.bindValueImpl(rv, v)
+	return bindString(rv, v, ctx)
 }
 
-func (binder StringBinder) bindValueImpl(rv reflect.Value, v string) error {
+func bindString(rv reflect.Value, v string, ctx bindContext) error {
+	if ok, err := bindKnownPointerType(rv, v, ctx); ok {
+		return err
+	}
+
 	rv = indirectVal(reflecting.AssignZero(rv))
 	var err error
 
-	if ok, err := bindKnownType(rv, v); ok {
+	if ok, err := bindKnownType(rv, v, ctx); ok {
 		return err
 	}
 
@@ -46,23 +61,32 @@ func (binder StringBinder) bindValueImpl(rv reflect.Value, v string) error {
 	case reflect.String:
 		rv.SetString(v)
 	case reflect.Array, reflect.Slice:
-		if len(v) > 0 {
-			array := strings.Split(v, ",")
-			size := len(array)
-			container := reflect.MakeSlice(rv.Type(), size, size)
-			for i, elem := range array {
-				err := binder.bindValueImpl(container.Index(i), elem)
-				if err != nil {
-					return &SliceBindingError{
-						Value: v,
-						Kind:  rv.Kind().String(),
-						Index: i,
-						Err:   err,
-					}
+		array, err := splitList(v, ctx.attrs)
+		if err != nil {
+			return &ValueBindingError{v, rv.Kind().String(), err}
+		}
+		size := len(array)
+		var container reflect.Value
+		if rv.Kind() == reflect.Array {
+			if size > rv.Len() {
+				return &ValueBindingError{v, rv.Type().String(), fmt.Errorf("%d elements exceed the array length %d", size, rv.Len())}
+			}
+			container = reflect.New(rv.Type()).Elem()
+		} else {
+			container = reflect.MakeSlice(rv.Type(), size, size)
+		}
+		for i, elem := range array {
+			err := bindString(container.Index(i), elem, ctx)
+			if err != nil {
+				return &SliceBindingError{
+					Value: v,
+					Kind:  rv.Kind().String(),
+					Index: i,
+					Err:   err,
 				}
 			}
-			rv.Set(container)
 		}
+		rv.Set(container)
 	default:
 		err = bindValue(rv, v)
 	}
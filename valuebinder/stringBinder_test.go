@@ -3,9 +3,13 @@ package valuebinder
 import (
 	"bytes"
 	"encoding/json"
+	"math/big"
 	"net"
+	"net/netip"
 	"net/url"
+	"os"
 	"reflect"
+	"regexp"
 	"testing"
 	"time"
 
@@ -224,3 +228,185 @@ func TestStringBinder_WithPtrInt(t *testing.T) {
 		t.Errorf("assert 'target':: expected '%#v', got '%#v'", 1, *target)
 	}
 }
+
+func TestStringBinder_WithStdTypes(t *testing.T) {
+	type Config struct {
+		Month    time.Month
+		Weekday  time.Weekday
+		Location *time.Location
+		Addr     netip.Addr
+		Prefix   netip.Prefix
+		Network  *net.IPNet
+		MAC      net.HardwareAddr
+		Pattern  *regexp.Regexp
+		Amount   *big.Int
+		Ratio    **big.Rat
+		Mode     os.FileMode
+		Endpoint *url.URL
+	}
+	var target Config
+	inputs := map[string]string{
+		"Month":    "mar",
+		"Weekday":  "Friday",
+		"Location": "UTC",
+		"Addr":     "::1",
+		"Prefix":   "10.0.0.0/8",
+		"Network":  "10.1.2.3/16",
+		"MAC":      "00:00:5e:00:53:01",
+		"Pattern":  `^\d+$`,
+		"Amount":   "123456789012345678901234567890",
+		"Ratio":    "3/4",
+		"Mode":     "0755",
+		"Endpoint": "https://example.com/api",
+	}
+
+	rv := reflect.ValueOf(&target).Elem()
+	for name, input := range inputs {
+		binder := StringBinder(rv.FieldByName(name))
+		if err := binder.Bind(input); err != nil {
+			t.Errorf("assert '%s':: unexpected error '%v'", name, err)
+		}
+	}
+
+	if target.Month != time.March {
+		t.Errorf("assert 'Config.Month':: expected '%v', got '%v'", time.March, target.Month)
+	}
+	if target.Weekday != time.Friday {
+		t.Errorf("assert 'Config.Weekday':: expected '%v', got '%v'", time.Friday, target.Weekday)
+	}
+	if target.Location != time.UTC {
+		t.Errorf("assert 'Config.Location':: expected '%v', got '%v'", time.UTC, target.Location)
+	}
+	if target.Addr != netip.IPv6Loopback() {
+		t.Errorf("assert 'Config.Addr':: expected '%v', got '%v'", netip.IPv6Loopback(), target.Addr)
+	}
+	if target.Prefix.String() != "10.0.0.0/8" {
+		t.Errorf("assert 'Config.Prefix':: expected '%v', got '%v'", "10.0.0.0/8", target.Prefix)
+	}
+	if target.Network == nil || target.Network.String() != "10.1.0.0/16" {
+		t.Errorf("assert 'Config.Network':: expected '%v', got '%v'", "10.1.0.0/16", target.Network)
+	}
+	if target.MAC.String() != "00:00:5e:00:53:01" {
+		t.Errorf("assert 'Config.MAC':: expected '%v', got '%v'", "00:00:5e:00:53:01", target.MAC)
+	}
+	if target.Pattern == nil || !target.Pattern.MatchString("42") {
+		t.Errorf("assert 'Config.Pattern':: expected '%v', got '%v'", `^\d+$`, target.Pattern)
+	}
+	if target.Amount == nil || target.Amount.String() != "123456789012345678901234567890" {
+		t.Errorf("assert 'Config.Amount':: expected '%v', got '%v'", "123456789012345678901234567890", target.Amount)
+	}
+	if target.Ratio == nil || (*target.Ratio).Cmp(big.NewRat(3, 4)) != 0 {
+		t.Errorf("assert 'Config.Ratio':: expected '%v', got '%v'", "3/4", target.Ratio)
+	}
+	if target.Mode != 0755 {
+		t.Errorf("assert 'Config.Mode':: expected '%v', got '%v'", os.FileMode(0755), target.Mode)
+	}
+	if target.Endpoint == nil || target.Endpoint.Host != "example.com" {
+		t.Errorf("assert 'Config.Endpoint':: expected '%v', got '%v'", "https://example.com/api", target.Endpoint)
+	}
+}
+
+func TestStringBinder_WithInvalidStdType(t *testing.T) {
+	var target *big.Int
+
+	rv := reflect.ValueOf(&target).Elem()
+	binder := StringBinder(rv)
+	err := binder.Bind("12.5")
+	if err == nil {
+		t.Errorf("assert 'error':: expected error, got '%v'", err)
+	}
+	if target != nil {
+		t.Errorf("assert 'target':: expected '%v', got '%v'", nil, target)
+	}
+}
+
+func TestStringBinder_WithListAttrs(t *testing.T) {
+	var cases = []struct {
+		input    string
+		attrs    map[string]string
+		expected []string
+	}{
+		{"a,b,c", nil, []string{"a", "b", "c"}},
+		{"", nil, []string{}},
+		{"a,,b,", nil, []string{"a", "", "b", ""}},
+		{" a , b ", nil, []string{" a ", " b "}},
+		{" a , b ", map[string]string{"trim": "true"}, []string{"a", "b"}},
+		{"   ", map[string]string{"trim": "true"}, []string{}},
+		{"a;b,c", map[string]string{"sep": ";"}, []string{"a", "b,c"}},
+		{"a::b", map[string]string{"sep": "::"}, []string{"a", "b"}},
+		{`"a,b",c`, map[string]string{"quote": "true"}, []string{"a,b", "c"}},
+		{`"say ""hi""",""`, map[string]string{"quote": "true"}, []string{`say "hi"`, ""}},
+		{` "a;b" ; c `, map[string]string{"sep": ";", "trim": "true", "quote": "true"}, []string{"a;b", "c"}},
+		{`a"b,c`, map[string]string{"quote": "true"}, []string{`a"b`, "c"}},
+		// the double quotes are ordinary characters without quote=true
+		{`"a,b`, nil, []string{`"a`, "b"}},
+		{`"a,b",c`, nil, []string{`"a`, `b"`, "c"}},
+		{`"a,b`, map[string]string{"quote": "false"}, []string{`"a`, "b"}},
+	}
+	for _, c := range cases {
+		var target []string
+
+		rv := reflect.ValueOf(&target).Elem()
+		binder := StringBinder(rv).WithAttrs(c.attrs)
+		err := binder.Bind(c.input)
+		if err != nil {
+			t.Errorf("assert 'Bind(%q)':: unexpected error '%v'", c.input, err)
+			continue
+		}
+		if !reflect.DeepEqual(c.expected, target) {
+			t.Errorf("assert 'Bind(%q)':: expected '%#v', got '%#v'", c.input, c.expected, target)
+		}
+	}
+}
+
+func TestStringBinder_WithInvalidList(t *testing.T) {
+	var cases = []struct {
+		input string
+		attrs map[string]string
+	}{
+		{`"a,b`, map[string]string{"quote": "true"}},
+		{`"a"b,c`, map[string]string{"quote": "true"}},
+		{"a,b", map[string]string{"quote": "yes please"}},
+		{"a,b", map[string]string{"sep": ""}},
+		{"a,b", map[string]string{"trim": "maybe"}},
+	}
+	for _, c := range cases {
+		var target []string
+
+		rv := reflect.ValueOf(&target).Elem()
+		binder := StringBinder(rv).WithAttrs(c.attrs)
+		err := binder.Bind(c.input)
+		if _, ok := err.(*ValueBindingError); !ok {
+			t.Errorf("assert 'Bind(%q, %v)':: expected *ValueBindingError, got '%v'", c.input, c.attrs, err)
+		}
+	}
+
+	// the empty element cannot be converted to int
+	var numbers []int
+	err := StringBinder(reflect.ValueOf(&numbers).Elem()).Bind("1,,3")
+	if _, ok := err.(*SliceBindingError); !ok {
+		t.Errorf("assert 'Bind()':: expected *SliceBindingError, got '%v'", err)
+	}
+}
+
+func TestStringBinder_WithArray(t *testing.T) {
+	var target [3]int
+	var input = "1,2"
+
+	rv := reflect.ValueOf(&target).Elem()
+	binder := StringBinder(rv)
+	err := binder.Bind(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := [3]int{1, 2, 0}
+	if target != expected {
+		t.Errorf("assert 'target':: expected '%v', got '%v'", expected, target)
+	}
+
+	err = binder.Bind("1,2,3,4")
+	if _, ok := err.(*ValueBindingError); !ok {
+		t.Errorf("assert 'Bind()':: expected *ValueBindingError, got '%v'", err)
+	}
+}
@@ -0,0 +1,124 @@
+package valuebinder
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/Bofry/structproto/common"
+)
+
+const (
+	defaultListSeparator = ","
+	listQuote            = `"`
+)
+
+// splitList splits v into the elements of a list by the attributes sep,
+// the separator, trim, which trims the white spaces around the elements,
+// and quote. With quote=true an element enclosed in double quotes can
+// contain the separator, and a double quote in it is escaped by another
+// one, e.g. `"a,b",c` and `"say ""hi"""`; otherwise the double quotes are
+// ordinary characters. The empty v is a list without elements, while the
+// empty elements, such as the middle one of "a,,b", are kept.
+func splitList(v string, attrs map[string]string) ([]string, error) {
+	trim, err := boolAttr(attrs, common.TrimAttr)
+	if err != nil {
+		return nil, err
+	}
+	quote, err := boolAttr(attrs, common.QuoteAttr)
+	if err != nil {
+		return nil, err
+	}
+	sep := defaultListSeparator
+	if s, ok := attrs[common.SepAttr]; ok {
+		if len(s) == 0 || (quote && strings.Contains(s, listQuote)) {
+			return nil, fmt.Errorf("invalid separator '%s'", s)
+		}
+		sep = s
+	}
+
+	if trim {
+		v = strings.TrimSpace(v)
+	}
+	if len(v) == 0 {
+		return []string{}, nil
+	}
+
+	if !quote || !strings.Contains(v, listQuote) {
+		elements := strings.Split(v, sep)
+		if trim {
+			for i, elem := range elements {
+				elements[i] = strings.TrimSpace(elem)
+			}
+		}
+		return elements, nil
+	}
+
+	var elements []string
+	for {
+		var elem string
+		if trim {
+			v = strings.TrimLeftFunc(v, unicode.IsSpace)
+		}
+		if strings.HasPrefix(v, listQuote) {
+			var err error
+			elem, v, err = unquoteListElement(v)
+			if err != nil {
+				return nil, err
+			}
+			if trim {
+				v = strings.TrimLeftFunc(v, unicode.IsSpace)
+			}
+			if len(v) > 0 && !strings.HasPrefix(v, sep) {
+				return nil, fmt.Errorf("unexpected '%s' after quoted element", v)
+			}
+		} else {
+			i := strings.Index(v, sep)
+			if i < 0 {
+				elem, v = v, ""
+			} else {
+				elem, v = v[:i], v[i:]
+			}
+			if trim {
+				elem = strings.TrimSpace(elem)
+			}
+		}
+		elements = append(elements, elem)
+		if len(v) == 0 {
+			return elements, nil
+		}
+		v = v[len(sep):]
+	}
+}
+
+// unquoteListElement returns the content of the quoted element at the
+// beginning of s and the rest after the closing quote.
+func unquoteListElement(s string) (string, string, error) {
+	var sb strings.Builder
+	for i := 1; i < len(s); i++ {
+		if s[i] == '"' {
+			if i+1 < len(s) && s[i+1] == '"' {
+				sb.WriteByte('"')
+				i++
+				continue
+			}
+			return sb.String(), s[i+1:], nil
+		}
+		sb.WriteByte(s[i])
+	}
+	return "", "", fmt.Errorf("unterminated quoted element '%s'", s)
+}
+
+// boolAttr returns the boolean value of the attribute key, false if absent.
+func boolAttr(attrs map[string]string, key string) (bool, error) {
+	s, ok := attrs[key]
+	if !ok {
+		return false, nil
+	}
+	v, err := strconv.ParseBool(s)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s '%s'", key, s)
+	}
+	return v, nil
+}
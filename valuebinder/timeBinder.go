@@ -0,0 +1,131 @@
+package valuebinder
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/Bofry/structproto/common"
+	"github.com/Bofry/structproto/valuebinder/converter"
+	"github.com/cstockton/go-conv"
+)
+
+var (
+	// timeLayouts are the names of the layouts in package time which can
+	// be used as the attribute layout, e.g. layout=DateOnly.
+	timeLayouts = map[string]string{
+		"Layout":      time.Layout,
+		"ANSIC":       time.ANSIC,
+		"UnixDate":    time.UnixDate,
+		"RubyDate":    time.RubyDate,
+		"RFC822":      time.RFC822,
+		"RFC822Z":     time.RFC822Z,
+		"RFC850":      time.RFC850,
+		"RFC1123":     time.RFC1123,
+		"RFC1123Z":    time.RFC1123Z,
+		"RFC3339":     time.RFC3339,
+		"RFC3339Nano": time.RFC3339Nano,
+		"Kitchen":     time.Kitchen,
+		"Stamp":       time.Stamp,
+		"StampMilli":  time.StampMilli,
+		"StampMicro":  time.StampMicro,
+		"StampNano":   time.StampNano,
+		"DateTime":    time.DateTime,
+		"DateOnly":    time.DateOnly,
+		"TimeOnly":    time.TimeOnly,
+	}
+
+	timeUnits = map[string]time.Duration{
+		"s":  time.Second,
+		"ms": time.Millisecond,
+		"us": time.Microsecond,
+		"ns": time.Nanosecond,
+	}
+
+	locations sync.Map
+)
+
+// bindTime binds v into time.Time by the attributes in ctx:
+//   - layout parses the text by the layout, or the name of a layout in
+//     package time; the zone-less text is parsed in tz, or UTC.
+//   - unix=s|ms|us|ns reads the number, or the text of it, as the time since
+//     the epoch in the unit.
+//   - tz converts the time to the location, except the text parsed by
+//     layout which carries its own zone.
+//
+// Without the attributes the numbers are the seconds since the epoch, and
+// the format of the text is guessed.
+func bindTime(rv reflect.Value, v interface{}, ctx bindContext) error {
+	t, err := convertTime(v, ctx.attrs)
+	if err != nil {
+		return &ValueBindingError{v, rv.Type().String(), err}
+	}
+	rv.Set(reflect.ValueOf(t))
+	return nil
+}
+
+func convertTime(v interface{}, attrs map[string]string) (time.Time, error) {
+	var loc *time.Location
+	if name, ok := attrs[common.TimeZoneAttr]; ok {
+		var err error
+		loc, err = loadLocation(name)
+		if err != nil {
+			return time.Time{}, err
+		}
+	}
+
+	if layout, ok := attrs[common.LayoutAttr]; ok {
+		if name, ok := timeLayouts[layout]; ok {
+			layout = name
+		}
+		return converter.TimeLayout(v, layout, loc)
+	}
+
+	var (
+		t   time.Time
+		err error
+	)
+	if name, ok := attrs[common.UnixAttr]; ok {
+		unit, ok := timeUnits[name]
+		if !ok {
+			return time.Time{}, fmt.Errorf("unknown unix unit '%s'", name)
+		}
+		t, err = converter.UnixTime(v, unit)
+	} else if isNumber(v) {
+		t, err = converter.UnixTime(v, time.Second)
+	} else {
+		t, err = conv.Time(v)
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	if loc != nil {
+		t = t.In(loc)
+	}
+	return t, nil
+}
+
+// loadLocation returns the location of name, the locations are cached as
+// time.LoadLocation reads the zone database on every call.
+func loadLocation(name string) (*time.Location, error) {
+	if loc, ok := locations.Load(name); ok {
+		return loc.(*time.Location), nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+	locations.Store(name, loc)
+	return loc, nil
+}
+
+func isNumber(v interface{}) bool {
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,125 @@
+package valuebinder
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestStringBinder_WithTimeLayout(t *testing.T) {
+	var target time.Time
+	var input = "2024/01/02 08:00"
+
+	rv := reflect.ValueOf(&target).Elem()
+	binder := StringBinder(rv).WithAttrs(map[string]string{
+		"layout": "2006/01/02 15:04",
+		"tz":     "Asia/Taipei",
+	})
+	err := binder.Bind(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	if !target.Equal(expected) {
+		t.Errorf("assert 'target':: expected '%v', got '%v'", expected, target)
+	}
+	if target.Location().String() != "Asia/Taipei" {
+		t.Errorf("assert 'target.Location()':: expected '%v', got '%v'", "Asia/Taipei", target.Location())
+	}
+}
+
+func TestBytesBinder_WithUnixTime(t *testing.T) {
+	var target time.Time
+	var input = []byte("1704153600123")
+
+	rv := reflect.ValueOf(&target).Elem()
+	binder := BytesBinder(rv).WithAttrs(map[string]string{
+		"unix": "ms",
+	})
+	err := binder.Bind(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := time.Date(2024, 1, 2, 0, 0, 0, 123000000, time.UTC)
+	if target != expected {
+		t.Errorf("assert 'target':: expected '%v', got '%v'", expected, target)
+	}
+}
+
+func TestScalarBinder_WithNumericTime(t *testing.T) {
+	var cases = []struct {
+		input    interface{}
+		attrs    map[string]string
+		expected time.Time
+	}{
+		{1704153600, nil, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{1704153600.5, nil, time.Date(2024, 1, 2, 0, 0, 0, 500000000, time.UTC)},
+		{int64(-1500), map[string]string{"unix": "ms"}, time.Date(1969, 12, 31, 23, 59, 58, 500000000, time.UTC)},
+		{uint64(1704153600000000000), map[string]string{"unix": "ns"}, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{"2024-01-02T00:00:00Z", nil, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, c := range cases {
+		var target time.Time
+
+		rv := reflect.ValueOf(&target).Elem()
+		binder := ScalarBinder(rv).WithAttrs(c.attrs)
+		err := binder.Bind(c.input)
+		if err != nil {
+			t.Errorf("assert 'Bind(%#v)':: unexpected error '%v'", c.input, err)
+			continue
+		}
+		if !target.Equal(c.expected) {
+			t.Errorf("assert 'Bind(%#v)':: expected '%v', got '%v'", c.input, c.expected, target)
+		}
+	}
+}
+
+func TestScalarBinder_WithInvalidTimeAttrs(t *testing.T) {
+	var cases = []struct {
+		input interface{}
+		attrs map[string]string
+	}{
+		{1704153600, map[string]string{"unix": "min"}},
+		{1704153600, map[string]string{"tz": "Mars/Olympus"}},
+		{"2024-01-02", map[string]string{"layout": "2006/01/02"}},
+		{"1704153600", nil},
+	}
+	for _, c := range cases {
+		var target time.Time
+
+		rv := reflect.ValueOf(&target).Elem()
+		binder := ScalarBinder(rv).WithAttrs(c.attrs)
+		err := binder.Bind(c.input)
+		if _, ok := err.(*ValueBindingError); !ok {
+			t.Errorf("assert 'Bind(%#v, %v)':: expected *ValueBindingError, got '%v'", c.input, c.attrs, err)
+		}
+	}
+}
+
+func TestScalarBinder_WithTypedTimeAndTimeZone(t *testing.T) {
+	var input = time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	for _, attrs := range []map[string]string{
+		{"tz": "Asia/Taipei"},
+		{"tz": "Asia/Taipei", "layout": "DateOnly"},
+		{"tz": "Asia/Taipei", "unix": "ms"},
+	} {
+		var target time.Time
+
+		rv := reflect.ValueOf(&target).Elem()
+		binder := ScalarBinder(rv).WithAttrs(attrs)
+		err := binder.Bind(input)
+		if err != nil {
+			t.Errorf("assert 'Bind(%v)':: unexpected error '%v'", attrs, err)
+			continue
+		}
+		if !target.Equal(input) {
+			t.Errorf("assert 'Bind(%v)':: expected '%v', got '%v'", attrs, input, target)
+		}
+		if target.Location().String() != "Asia/Taipei" {
+			t.Errorf("assert 'Bind(%v).Location()':: expected '%v', got '%v'", attrs, "Asia/Taipei", target.Location())
+		}
+	}
+}
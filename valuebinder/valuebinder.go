@@ -1,6 +1,8 @@
 package valuebinder
 
 import (
+	"encoding"
+	"encoding/json"
 	"fmt"
 	"reflect"
 
@@ -10,20 +12,57 @@ import (
 )
 
 var (
-	knownTypeBinderTable = map[reflect.Type]typeBinder{
+	knownTypeBinderTable = map[reflect.Type]Converter{
 		typeOfDuration:   bindDuration,
 		typeOfRawContent: bindRawContent,
 		typeOfRawMessage: bindRawMessage,
 		typeOfIP:         bindIP,
 		typeOfUrl:        bindUrl,
-		typeOfTime:       bindTime,
 		typeOfBuffer:     bindBuffer,
+
+		typeOfMonth:        bindConverted(converter.Month),
+		typeOfWeekday:      bindConverted(converter.Weekday),
+		typeOfAddr:         bindConverted(converter.Addr),
+		typeOfAddrPort:     bindConverted(converter.AddrPort),
+		typeOfPrefix:       bindConverted(converter.Prefix),
+		typeOfHardwareAddr: bindConverted(converter.HardwareAddr),
+		typeOfMailAddress:  bindConverted(converter.MailAddress),
+		typeOfFileMode:     bindConverted(converter.FileMode),
+	}
+
+	// knownAttrTypeBinderTable keeps the types whose conversions are
+	// specialized by the attributes of the field.
+	knownAttrTypeBinderTable = map[reflect.Type]func(rv reflect.Value, v interface{}, ctx bindContext) error{
+		typeOfTime: bindTime,
+	}
+
+	// knownPointerTypeBinderTable keeps the types which are used by pointer,
+	// their values are assigned without copying the pointees.
+	knownPointerTypeBinderTable = map[reflect.Type]Converter{
+		typeOfLocationPtr: bindConverted(converter.Location),
+		typeOfIPNetPtr:    bindConverted(converter.IPNet),
+		typeOfRegexpPtr:   bindConverted(converter.Regexp),
+		typeOfBigIntPtr:   bindConverted(converter.BigInt),
+		typeOfBigFloatPtr: bindConverted(converter.BigFloat),
+		typeOfBigRatPtr:   bindConverted(converter.BigRat),
+		typeOfUrlPtr:      bindConverted(converter.UrlPtr),
 	}
 
 	errBindingUnsupportedType = fmt.Errorf("cannot bind specified type")
 )
 
-func bindKnownType(rv reflect.Value, v interface{}) (bool, error) {
+// bindKnownType binds v in the precedence order:
+//  1. the converter of rv's type in converters
+//  2. common.Unmarshaler
+//  3. the built-in converters in knownAttrTypeBinderTable and
+//     knownTypeBinderTable
+//  4. json.Unmarshaler if v is json.RawMessage, or
+//     encoding.TextUnmarshaler if v is string
+func bindKnownType(rv reflect.Value, v interface{}, ctx bindContext) (bool, error) {
+	if converter, ok := ctx.converters.Lookup(rv.Type()); ok {
+		return true, converter(rv, v)
+	}
+
 	if reflect.PointerTo(rv.Type()).Implements(typeOfUnmarshaler) {
 		u := reflect.New(rv.Type())
 		err := bindUnmarshaler(u, v)
@@ -34,12 +73,86 @@ func bindKnownType(rv reflect.Value, v interface{}) (bool, error) {
 		return true, nil
 	}
 
+	if binder, ok := knownAttrTypeBinderTable[rv.Type()]; ok {
+		return true, binder(rv, v, ctx)
+	}
 	if binder, ok := knownTypeBinderTable[rv.Type()]; ok {
 		return true, binder(rv, v)
 	}
+
+	switch v := v.(type) {
+	case json.RawMessage:
+		if reflect.PointerTo(rv.Type()).Implements(typeOfJsonUnmarshaler) {
+			return true, bindStdUnmarshaler(rv, v, func(u interface{}) error {
+				return u.(json.Unmarshaler).UnmarshalJSON(v)
+			})
+		}
+	case string:
+		if reflect.PointerTo(rv.Type()).Implements(typeOfTextUnmarshaler) {
+			return true, bindStdUnmarshaler(rv, v, func(u interface{}) error {
+				return u.(encoding.TextUnmarshaler).UnmarshalText([]byte(v))
+			})
+		}
+	}
+	return false, nil
+}
+
+// bindKnownPointerType binds v if rv is a pointer, or a pointer to pointer,
+// of the types in knownPointerTypeBinderTable or registered in converters.
+func bindKnownPointerType(rv reflect.Value, v interface{}, ctx bindContext) (bool, error) {
+	for t := rv.Type(); t.Kind() == reflect.Ptr; t = t.Elem() {
+		binder, ok := ctx.converters.Lookup(t)
+		if !ok {
+			if _, ok := ctx.converters.Lookup(t.Elem()); ok {
+				// the converter of the pointee takes precedence
+				return false, nil
+			}
+			binder, ok = knownPointerTypeBinderTable[t]
+		}
+		if ok {
+			// allocate the outer pointers
+			for rv.Type() != t {
+				if rv.IsNil() {
+					rv.Set(reflect.New(rv.Type().Elem()))
+				}
+				rv = rv.Elem()
+			}
+			return true, binder(rv, v)
+		}
+	}
 	return false, nil
 }
 
+// bindBinaryUnmarshaler binds v by encoding.BinaryUnmarshaler if rv's type
+// implements it and is not handled by the converters precede it.
+func bindBinaryUnmarshaler(rv reflect.Value, v []byte) (bool, error) {
+	ptr := reflect.PointerTo(rv.Type())
+	if !ptr.Implements(typeOfBinaryUnmarshaler) || ptr.Implements(typeOfUnmarshaler) {
+		return false, nil
+	}
+	if _, ok := knownTypeBinderTable[rv.Type()]; ok {
+		return false, nil
+	}
+	if _, ok := knownAttrTypeBinderTable[rv.Type()]; ok {
+		return false, nil
+	}
+	return true, bindStdUnmarshaler(rv, v, func(u interface{}) error {
+		return u.(encoding.BinaryUnmarshaler).UnmarshalBinary(v)
+	})
+}
+
+// bindStdUnmarshaler calls unmarshal on a new value of rv's type, and
+// assigns it to rv if succeeded.
+func bindStdUnmarshaler(rv reflect.Value, v interface{}, unmarshal func(u interface{}) error) error {
+	u := reflect.New(rv.Type())
+	err := unmarshal(u.Interface())
+	if err != nil {
+		return &ValueBindingError{v, rv.Type().String(), err}
+	}
+	rv.Set(u.Elem())
+	return nil
+}
+
 func bindValue(rv reflect.Value, v interface{}) error {
 	switch rv.Kind() {
 	case reflect.Bool:
@@ -78,6 +191,18 @@ func bindValue(rv reflect.Value, v interface{}) error {
 	return nil
 }
 
+// bindConverted returns the Converter binds the result of convert.
+func bindConverted[T any](convert func(from interface{}) (T, error)) Converter {
+	return func(rv reflect.Value, v interface{}) error {
+		value, err := convert(v)
+		if err != nil {
+			return &ValueBindingError{v, rv.Type().String(), err}
+		}
+		rv.Set(reflect.ValueOf(value))
+		return nil
+	}
+}
+
 func bindDuration(rv reflect.Value, v interface{}) error {
 	duration, err := conv.Duration(v)
 	if err != nil {
@@ -123,15 +248,6 @@ func bindUrl(rv reflect.Value, v interface{}) error {
 	return nil
 }
 
-func bindTime(rv reflect.Value, v interface{}) error {
-	time, err := conv.Time(v)
-	if err != nil {
-		return &ValueBindingError{v, rv.Type().String(), err}
-	}
-	rv.Set(reflect.ValueOf(time))
-	return nil
-}
-
 func bindBuffer(rv reflect.Value, v interface{}) error {
 	buf, err := converter.Buffer(v)
 	if err != nil {
@@ -0,0 +1,149 @@
+package valuebinder
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/netip"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type level int
+
+func (l *level) UnmarshalText(text []byte) error {
+	switch strings.ToLower(string(text)) {
+	case "debug":
+		*l = 1
+	case "info":
+		*l = 2
+	default:
+		return fmt.Errorf("unknown level '%s'", text)
+	}
+	return nil
+}
+
+type version struct {
+	major, minor byte
+}
+
+func (v *version) UnmarshalBinary(data []byte) error {
+	if len(data) != 2 {
+		return fmt.Errorf("invalid version")
+	}
+	v.major, v.minor = data[0], data[1]
+	return nil
+}
+
+type point struct {
+	X, Y int
+}
+
+func (p *point) UnmarshalJSON(data []byte) error {
+	var v [2]int
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	p.X, p.Y = v[0], v[1]
+	return nil
+}
+
+// mode implements both common.Unmarshaler and encoding.TextUnmarshaler.
+type mode string
+
+func (m *mode) UnmarshalStruct(v interface{}) error {
+	*m = mode(fmt.Sprintf("struct:%v", v))
+	return nil
+}
+
+func (m *mode) UnmarshalText(text []byte) error {
+	*m = mode("text:" + string(text))
+	return nil
+}
+
+func TestStringBinder_WithTextUnmarshaler(t *testing.T) {
+	{
+		var target netip.Addr
+		err := StringBinder(reflect.ValueOf(&target).Elem()).Bind("192.168.0.1")
+		if err != nil {
+			t.Error(err)
+		}
+		if target != netip.MustParseAddr("192.168.0.1") {
+			t.Errorf("assert 'target':: expected '%v', got '%v'", "192.168.0.1", target)
+		}
+	}
+	{
+		var target *big.Int
+		err := StringBinder(reflect.ValueOf(&target).Elem()).Bind("123456789012345678901234567890")
+		if err != nil {
+			t.Error(err)
+		}
+		if target == nil || target.String() != "123456789012345678901234567890" {
+			t.Errorf("assert 'target':: expected '%v', got '%v'", "123456789012345678901234567890", target)
+		}
+	}
+	{
+		var target []level
+		err := StringBinder(reflect.ValueOf(&target).Elem()).Bind("debug,INFO")
+		if err != nil {
+			t.Error(err)
+		}
+		expected := []level{1, 2}
+		if !reflect.DeepEqual(expected, target) {
+			t.Errorf("assert 'target':: expected '%#v', got '%#v'", expected, target)
+		}
+	}
+	{
+		var target level
+		err := StringBinder(reflect.ValueOf(&target).Elem()).Bind("trace")
+		if err == nil {
+			t.Errorf("assert 'StringBinder.Bind()':: expected error")
+		}
+	}
+	// common.Unmarshaler takes precedence over encoding.TextUnmarshaler
+	{
+		var target mode
+		err := StringBinder(reflect.ValueOf(&target).Elem()).Bind("fast")
+		if err != nil {
+			t.Error(err)
+		}
+		if target != "struct:fast" {
+			t.Errorf("assert 'target':: expected '%v', got '%v'", "struct:fast", target)
+		}
+	}
+}
+
+func TestBytesBinder_WithBinaryUnmarshaler(t *testing.T) {
+	var target version
+	err := BytesBinder(reflect.ValueOf(&target).Elem()).Bind([]byte{1, 2})
+	if err != nil {
+		t.Error(err)
+	}
+	expected := version{1, 2}
+	if target != expected {
+		t.Errorf("assert 'target':: expected '%v', got '%v'", expected, target)
+	}
+
+	// the TextUnmarshaler is used if BinaryUnmarshaler is not implemented
+	var lv level
+	err = BytesBinder(reflect.ValueOf(&lv).Elem()).Bind([]byte("info"))
+	if err != nil {
+		t.Error(err)
+	}
+	if lv != 2 {
+		t.Errorf("assert 'target':: expected '%v', got '%v'", 2, lv)
+	}
+}
+
+func TestScalarBinder_WithJsonUnmarshaler(t *testing.T) {
+	var target *point
+	err := ScalarBinder(reflect.ValueOf(&target).Elem()).Bind(json.RawMessage(`[3,4]`))
+	if err != nil {
+		t.Error(err)
+	}
+	expected := &point{3, 4}
+	if !reflect.DeepEqual(expected, target) {
+		t.Errorf("assert 'target':: expected '%v', got '%v'", expected, target)
+	}
+}